@@ -2,6 +2,7 @@ package chiopenapi
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oaswrap/spec"
@@ -9,12 +10,14 @@ import (
 	"github.com/oaswrap/spec/adapter/chiopenapi/internal/constant"
 	"github.com/oaswrap/spec/option"
 	"github.com/oaswrap/spec/pkg/mapper"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 type router struct {
 	chiRouter  chi.Router
 	specRouter spec.Router
 	gen        spec.Generator
+	mu         *sync.Mutex // Serializes registration on the Chi routers of the tree.
 }
 
 var _ Router = (*router)(nil)
@@ -45,6 +48,7 @@ func NewGenerator(r chi.Router, opts ...option.OpenAPIOption) Generator {
 		chiRouter:  r,
 		specRouter: gen,
 		gen:        gen,
+		mu:         &sync.Mutex{},
 	}
 
 	if cfg.DisableDocs {
@@ -64,59 +68,80 @@ func (r *router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 }
 
 func (r *router) Use(middlewares ...func(http.Handler) http.Handler) {
-	r.chiRouter.Use(middlewares...)
+	r.register(func() {
+		r.chiRouter.Use(middlewares...)
+	})
 }
 
 func (r *router) With(middlewares ...func(http.Handler) http.Handler) Router {
-	cr := r.chiRouter.With(middlewares...)
+	var cr chi.Router
+	r.register(func() {
+		cr = r.chiRouter.With(middlewares...)
+	})
 
 	return &router{
 		chiRouter:  cr,
 		specRouter: r.specRouter,
 		gen:        r.gen,
+		mu:         r.mu,
 	}
 }
 
+// Group creates an inline group like chi.Mux.Group, without holding the registration
+// lock while fn registers its routes.
 func (r *router) Group(fn func(r Router), opts ...option.GroupOption) Router {
-	var group *router
-	r.chiRouter.Group(func(chiRouter chi.Router) {
-		group = &router{
-			chiRouter:  chiRouter,
-			specRouter: r.specRouter.Group("/", opts...),
-			gen:        r.gen,
-		}
-		fn(group)
+	var chiRouter chi.Router
+	r.register(func() {
+		chiRouter = r.chiRouter.With()
 	})
+	group := &router{
+		chiRouter:  chiRouter,
+		specRouter: r.specRouter.Group("/", opts...),
+		gen:        r.gen,
+		mu:         r.mu,
+	}
+	if fn != nil {
+		fn(group)
+	}
 	return group
 }
 
+// Route mounts a new sub-router like chi.Mux.Route, without holding the registration
+// lock while fn registers its routes.
 func (r *router) Route(pattern string, fn func(r Router), opts ...option.GroupOption) Router {
-	var subRouter *router
-	r.chiRouter.Route(pattern, func(chiRouter chi.Router) {
-		subRouter = &router{
-			chiRouter:  chiRouter,
-			specRouter: r.specRouter.Group(pattern, opts...),
-			gen:        r.gen,
-		}
-		fn(subRouter)
-	})
+	subRouter := &router{
+		chiRouter:  chi.NewRouter(),
+		specRouter: r.specRouter.Group(pattern, opts...),
+		gen:        r.gen,
+		mu:         r.mu,
+	}
+	fn(subRouter)
+	r.Mount(pattern, subRouter.chiRouter)
 	return subRouter
 }
 
 func (r *router) Handle(pattern string, h http.Handler) {
-	r.chiRouter.Handle(pattern, h)
+	r.register(func() {
+		r.chiRouter.Handle(pattern, h)
+	})
 }
 
 func (r *router) HandleFunc(pattern string, h http.HandlerFunc) {
-	r.chiRouter.HandleFunc(pattern, h)
+	r.register(func() {
+		r.chiRouter.HandleFunc(pattern, h)
+	})
 }
 
 func (r *router) Mount(pattern string, h http.Handler) {
-	r.chiRouter.Mount(pattern, h)
+	r.register(func() {
+		r.chiRouter.Mount(pattern, h)
+	})
 }
 
 func (r *router) Method(method, pattern string, h http.Handler) Route {
-	r.chiRouter.Method(method, pattern, h)
+	r.register(func() {
+		r.chiRouter.Method(method, pattern, h)
+	})
 	if method == http.MethodConnect {
 		// CONNECT method is not supported by OpenAPI, so we skip it
 		return &route{}
@@ -127,7 +152,9 @@ func (r *router) Method(method, pattern string, h http.Handler) Route {
 }
 
 func (r *router) MethodFunc(method, pattern string, h http.HandlerFunc) Route {
-	r.chiRouter.MethodFunc(method, pattern, h)
+	r.register(func() {
+		r.chiRouter.MethodFunc(method, pattern, h)
+	})
 	if method == http.MethodConnect {
 		// CONNECT method is not supported by OpenAPI, so we skip it
 		return &route{}
@@ -174,11 +201,15 @@ func (r *router) Trace(pattern string, h http.HandlerFunc) Route {
 }
 
 func (r *router) NotFound(h http.HandlerFunc) {
-	r.chiRouter.NotFound(h)
+	r.register(func() {
+		r.chiRouter.NotFound(h)
+	})
 }
 
 func (r *router) MethodNotAllowed(h http.HandlerFunc) {
-	r.chiRouter.MethodNotAllowed(h)
+	r.register(func() {
+		r.chiRouter.MethodNotAllowed(h)
+	})
 }
 
 func (r *router) WithOptions(opts ...option.GroupOption) Router {
@@ -186,8 +217,20 @@ func (r *router) WithOptions(opts ...option.GroupOption) Router {
 	return r
 }
 
-func (r *router) Validate() error {
-	return r.gen.Validate()
+func (r *router) Validate(opts ...spec.ValidateOption) error {
+	return r.gen.Validate(opts...)
+}
+
+func (r *router) Document() any {
+	return r.gen.Document()
+}
+
+func (r *router) Operations() []spec.Operation {
+	return r.gen.Operations()
+}
+
+func (r *router) Webhook(name, method string, opts ...option.OperationOption) {
+	r.gen.Webhook(name, method, opts...)
 }
 
 func (r *router) GenerateSchema(formats ...string) ([]byte, error) {
@@ -205,3 +248,16 @@ func (r *router) MarshalJSON() ([]byte, error) {
 func (r *router) WriteSchemaTo(filename string) error {
 	return r.gen.WriteSchemaTo(filename)
 }
+
+func (r *router) RequestValidator(opts ...validator.Option) func(http.Handler) http.Handler {
+	return validator.NewRequestValidator(r.gen, opts...).Middleware
+}
+
+// register runs fn with the registration lock held, since Chi routers do not support
+// concurrent registration.
+func (r *router) register(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn()
+}
@@ -3,10 +3,12 @@ package chiopenapi_test
 import (
 	"encoding/json"
 	"flag"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -659,3 +661,145 @@ func TestGenerator_WriteSchemaTo(t *testing.T) {
 	assert.Contains(t, string(schema), "openapi: 3.0.3", "expected OpenAPI version in schema file")
 	assert.Contains(t, string(schema), "title: Chi OpenAPI", "expected title in schema file")
 }
+
+func TestGenerator_RequestValidator(t *testing.T) {
+	type ListPetsRequest struct {
+		Limit int `query:"limit" minimum:"1"`
+	}
+	c := chi.NewRouter()
+	r := chiopenapi.NewRouter(c)
+	r.With(r.RequestValidator()).Get("/pets", pingHandler).With(
+		option.Request(new(ListPetsRequest)),
+	)
+
+	t.Run("valid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pets?limit=10", nil)
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "pong")
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pets?limit=0", nil)
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"limit"`)
+	})
+}
+
+func TestGenerator_Operations(t *testing.T) {
+	r := chiopenapi.NewRouter(chi.NewRouter())
+	r.Route("/pets", func(r chiopenapi.Router) {
+		r.Get("/{petId}", nil).With(option.Summary("Get pet"))
+	}, option.GroupTags("pets"))
+
+	ops := r.Operations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, "GET", ops[0].Method)
+	assert.Equal(t, "/pets/{petId}", ops[0].Path)
+	assert.Equal(t, "Get pet", ops[0].Config.Summary)
+	assert.Equal(t, []string{"pets"}, ops[0].Config.Tags)
+	assert.False(t, ops[0].Hidden)
+}
+
+func TestGenerator_Document(t *testing.T) {
+	var transformed any
+	r := chiopenapi.NewRouter(chi.NewRouter(), option.WithSpecTransformer(func(doc any) error {
+		transformed = doc
+		return nil
+	}))
+	r.Get("/pets", nil).With(option.Summary("List pets"))
+
+	doc := r.Document()
+	require.NotNil(t, doc)
+	assert.Same(t, transformed, doc)
+}
+
+func TestGenerator_LateRoutes(t *testing.T) {
+	c := chi.NewRouter()
+	r := chiopenapi.NewRouter(c)
+	serveSpec := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil)
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	r.Get("/ping", pingHandler)
+	assert.Contains(t, serveSpec(), "/ping:")
+
+	r.Get("/pong", pingHandler)
+	assert.Contains(t, serveSpec(), "/pong:", "expected route added after the spec was served")
+}
+
+func TestGenerator_ConflictingRoutes(t *testing.T) {
+	c := chi.NewRouter()
+	r := chiopenapi.NewRouter(c)
+
+	r.Get("/users/{userId}", pingHandler).With(option.Request(new(struct {
+		ID string `path:"userId"`
+	})))
+	r.Route("/users", func(r chiopenapi.Router) {
+		r.Get("/{id}", pingHandler).With(option.Request(new(struct {
+			ID string `path:"id"`
+		})))
+	})
+
+	err := r.Validate()
+	require.Error(t, err)
+	assert.Equal(t, "Spec errors:\n"+
+		"- route GET /users/{id} conflicts with GET /users/{userId}: paths differ only in parameter names\n",
+		err.Error())
+}
+
+func TestGenerator_ConcurrentRegistration(t *testing.T) {
+	c := chi.NewRouter()
+	r := chiopenapi.NewRouter(c)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(version string) {
+			defer wg.Done()
+			r.HandleFunc(version+"/health", pingHandler)
+			r.Route(version, func(r chiopenapi.Router) {
+				r.Get("/pets", pingHandler).With(option.Summary("List pets"))
+			}, option.GroupTags("pets"))
+			r.Group(func(r chiopenapi.Router) {
+				r.Post(version+"/orders", pingHandler)
+			})
+			_, err := r.MarshalYAML()
+			assert.NoError(t, err)
+		}(fmt.Sprintf("/v%d", i))
+	}
+	wg.Wait()
+
+	assert.Len(t, r.Operations(), 20)
+	for i := 0; i < 10; i++ {
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v%d/health", i), nil),
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v%d/pets", i), nil),
+			httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v%d/orders", i), nil),
+		} {
+			rec := httptest.NewRecorder()
+			c.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code, req.URL.Path)
+		}
+	}
+}
+
+func TestGenerator_Webhook(t *testing.T) {
+	r := chiopenapi.NewRouter(chi.NewRouter(), option.WithOpenAPIVersion("3.1.0"))
+	r.Webhook("petCreated", "POST",
+		option.Summary("Pet created"),
+		option.Request(new(struct {
+			ID string `json:"id"`
+		})),
+	)
+
+	schema, err := r.MarshalYAML()
+	require.NoError(t, err, "failed to marshal OpenAPI schema to YAML")
+	assert.Contains(t, string(schema), "webhooks:\n  petCreated:\n    post:", "expected webhook in schema YAML")
+}
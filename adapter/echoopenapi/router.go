@@ -2,6 +2,7 @@ package echoopenapi
 
 import (
 	"io/fs"
+	"sync"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oaswrap/spec"
@@ -10,12 +11,14 @@ import (
 	"github.com/oaswrap/spec/option"
 	"github.com/oaswrap/spec/pkg/mapper"
 	"github.com/oaswrap/spec/pkg/parser"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 type router struct {
 	echoGroup  *echo.Group
 	specRouter spec.Router
 	gen        spec.Generator
+	mu         *sync.Mutex // Serializes registration on the Echo instance.
 }
 
 // NewRouter creates a new OpenAPI router with the provided Echo instance and options.
@@ -45,6 +48,7 @@ func NewGenerator(e *echo.Echo, opts ...option.OpenAPIOption) Generator {
 		echoGroup:  e.Group(""),
 		specRouter: gen,
 		gen:        gen,
+		mu:         &sync.Mutex{},
 	}
 
 	if cfg.DisableDocs {
@@ -54,13 +58,16 @@ func NewGenerator(e *echo.Echo, opts ...option.OpenAPIOption) Generator {
 	handler := specui.NewHandler(mapper.SpecUIOpts(gen)...)
 
 	rr.echoGroup.GET(cfg.DocsPath, echo.WrapHandler(handler.Docs()))
-	rr.echoGroup.GET(cfg.SpecPath, echo.WrapHandler(handler.Spec()))
+	rr.echoGroup.GET(cfg.SpecPath, echo.WrapHandler(handler.SpecFunc()))
 
 	return rr
 }
 
 func (r *router) Add(method, path string, handler echo.HandlerFunc, m ...echo.MiddlewareFunc) Route {
-	echoRoute := r.echoGroup.Add(method, path, handler, m...)
+	var echoRoute *echo.Route
+	r.register(func() {
+		echoRoute = r.echoGroup.Add(method, path, handler, m...)
+	})
 	route := &route{echoRoute: echoRoute}
 
 	if method == echo.CONNECT {
@@ -109,35 +116,49 @@ func (r *router) CONNECT(path string, handler echo.HandlerFunc, m ...echo.Middle
 }
 
 func (r *router) Group(prefix string, m ...echo.MiddlewareFunc) Router {
-	group := r.echoGroup.Group(prefix, m...)
+	var group *echo.Group
+	r.register(func() {
+		group = r.echoGroup.Group(prefix, m...)
+	})
 	specGroup := r.specRouter.Group(prefix)
 
 	return &router{
 		echoGroup:  group,
 		specRouter: specGroup,
 		gen:        r.gen,
+		mu:         r.mu,
 	}
 }
 
 func (r *router) Use(m ...echo.MiddlewareFunc) Router {
-	r.echoGroup.Use(m...)
+	r.register(func() {
+		r.echoGroup.Use(m...)
+	})
 	return r
 }
 
 func (r *router) File(path, file string) {
-	r.echoGroup.File(path, file)
+	r.register(func() {
+		r.echoGroup.File(path, file)
+	})
 }
 
 func (r *router) FileFS(path, file string, fs fs.FS, m ...echo.MiddlewareFunc) {
-	r.echoGroup.FileFS(path, file, fs, m...)
+	r.register(func() {
+		r.echoGroup.FileFS(path, file, fs, m...)
+	})
 }
 
 func (r *router) Static(prefix, root string) {
-	r.echoGroup.Static(prefix, root)
+	r.register(func() {
+		r.echoGroup.Static(prefix, root)
+	})
 }
 
 func (r *router) StaticFS(prefix string, fs fs.FS) {
-	r.echoGroup.StaticFS(prefix, fs)
+	r.register(func() {
+		r.echoGroup.StaticFS(prefix, fs)
+	})
 }
 
 func (r *router) With(opts ...option.GroupOption) Router {
@@ -161,6 +182,40 @@ func (r *router) GenerateSchema(format ...string) ([]byte, error) {
 	return r.gen.GenerateSchema(format...)
 }
 
-func (r *router) Validate() error {
-	return r.gen.Validate()
+func (r *router) Validate(opts ...spec.ValidateOption) error {
+	return r.gen.Validate(opts...)
+}
+
+func (r *router) Document() any {
+	return r.gen.Document()
+}
+
+func (r *router) Operations() []spec.Operation {
+	return r.gen.Operations()
+}
+
+func (r *router) Webhook(name, method string, opts ...option.OperationOption) {
+	r.gen.Webhook(name, method, opts...)
+}
+
+func (r *router) RequestValidator(opts ...validator.Option) echo.MiddlewareFunc {
+	v := validator.NewRequestValidator(r.gen, opts...)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if err := v.Validate(c.Request()); err != nil {
+				v.HandleError(c.Response(), c.Request(), err)
+				return nil
+			}
+			return next(c)
+		}
+	}
+}
+
+// register runs fn with the registration lock held, since Echo does not support
+// concurrent registration.
+func (r *router) register(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn()
 }
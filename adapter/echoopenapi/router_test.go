@@ -3,9 +3,11 @@ package echoopenapi_test
 import (
 	"flag"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -654,3 +656,124 @@ func TestGenerator_DisableDocs(t *testing.T) {
 
 	assert.Equal(t, 404, rec.Code, "Expected status code 404 for /docs when docs are disabled")
 }
+
+func TestGenerator_RequestValidator(t *testing.T) {
+	type ListPetsRequest struct {
+		Limit int `query:"limit" minimum:"1"`
+	}
+	e := echo.New()
+	r := echoopenapi.NewRouter(e)
+	r.Use(r.RequestValidator())
+	r.GET("/pets", func(c echo.Context) error {
+		return c.String(http.StatusOK, "pong")
+	}).With(
+		option.Request(new(ListPetsRequest)),
+	)
+
+	t.Run("valid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pets?limit=10", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "pong", rec.Body.String())
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pets?limit=0", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"limit"`)
+	})
+}
+
+func TestGenerator_Operations(t *testing.T) {
+	r := echoopenapi.NewRouter(echo.New())
+	r.Group("/pets").With(option.GroupTags("pets")).
+		GET("/:petId", DummyHandler).With(option.Summary("Get pet"))
+
+	ops := r.Operations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, "GET", ops[0].Method)
+	assert.Equal(t, "/pets/{petId}", ops[0].Path)
+	assert.Equal(t, "Get pet", ops[0].Config.Summary)
+	assert.Equal(t, []string{"pets"}, ops[0].Config.Tags)
+	assert.False(t, ops[0].Hidden)
+}
+
+func TestGenerator_Document(t *testing.T) {
+	var transformed any
+	r := echoopenapi.NewRouter(echo.New(), option.WithSpecTransformer(func(doc any) error {
+		transformed = doc
+		return nil
+	}))
+	r.GET("/pets", DummyHandler).With(option.Summary("List pets"))
+
+	doc := r.Document()
+	require.NotNil(t, doc)
+	assert.Same(t, transformed, doc)
+}
+
+func TestGenerator_LateRoutes(t *testing.T) {
+	e := echo.New()
+	r := echoopenapi.NewRouter(e)
+	serveSpec := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	r.GET("/ping", DummyHandler)
+	assert.Contains(t, serveSpec(), "/ping:")
+
+	r.GET("/pong", DummyHandler)
+	assert.Contains(t, serveSpec(), "/pong:", "expected route added after the spec was served")
+}
+
+func TestGenerator_ConcurrentRegistration(t *testing.T) {
+	e := echo.New()
+	r := echoopenapi.NewRouter(e)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(version string) {
+			defer wg.Done()
+			r.GET(version+"/health", DummyHandler)
+			g := r.Group(version).With(option.GroupTags("pets"))
+			g.GET("/pets", DummyHandler).With(option.Summary("List pets"))
+			g.POST("/orders", DummyHandler)
+			_, err := r.MarshalYAML()
+			assert.NoError(t, err)
+		}(fmt.Sprintf("/v%d", i))
+	}
+	wg.Wait()
+
+	assert.Len(t, r.Operations(), 30)
+	for i := 0; i < 10; i++ {
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v%d/health", i), nil),
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v%d/pets", i), nil),
+			httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v%d/orders", i), nil),
+		} {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code, req.URL.Path)
+		}
+	}
+}
+
+func TestGenerator_Webhook(t *testing.T) {
+	r := echoopenapi.NewRouter(echo.New(), option.WithOpenAPIVersion("3.1.0"))
+	r.Webhook("petCreated", "POST",
+		option.Summary("Pet created"),
+		option.Request(new(struct {
+			ID string `json:"id"`
+		})),
+	)
+
+	schema, err := r.MarshalYAML()
+	require.NoError(t, err, "failed to marshal OpenAPI schema to YAML")
+	assert.Contains(t, string(schema), "webhooks:\n  petCreated:\n    post:", "expected webhook in schema YAML")
+}
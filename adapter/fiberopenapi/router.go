@@ -1,6 +1,8 @@
 package fiberopenapi
 
 import (
+	"sync"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/oaswrap/spec"
@@ -9,6 +11,7 @@ import (
 	"github.com/oaswrap/spec/option"
 	"github.com/oaswrap/spec/pkg/mapper"
 	"github.com/oaswrap/spec/pkg/parser"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 // NewGenerator creates a new OpenAPI generator with the specified Fiber router and options.
@@ -38,6 +41,7 @@ func NewRouter(r fiber.Router, opts ...option.OpenAPIOption) Generator {
 		fiberRouter: r,
 		specRouter:  gen,
 		gen:         gen,
+		mu:          &sync.Mutex{},
 	}
 
 	// If docs are disabled, return the router without adding docs routes.
@@ -48,7 +52,7 @@ func NewRouter(r fiber.Router, opts ...option.OpenAPIOption) Generator {
 	handler := specui.NewHandler(mapper.SpecUIOpts(gen)...)
 
 	r.Get(cfg.DocsPath, adaptor.HTTPHandler(handler.Docs()))
-	r.Get(cfg.SpecPath, adaptor.HTTPHandler(handler.Spec()))
+	r.Get(cfg.SpecPath, adaptor.HTTPHandler(handler.SpecFunc()))
 
 	return rr
 }
@@ -57,10 +61,13 @@ type router struct {
 	fiberRouter fiber.Router
 	specRouter  spec.Router
 	gen         spec.Generator
+	mu          *sync.Mutex // Serializes registration on the Fiber app.
 }
 
 func (r *router) Use(args ...any) Router {
-	r.fiberRouter.Use(args...)
+	r.register(func() {
+		r.fiberRouter.Use(args...)
+	})
 	return r
 }
 
@@ -101,7 +108,10 @@ func (r *router) Trace(path string, handler ...fiber.Handler) Route {
 }
 
 func (r *router) Add(method, path string, handler ...fiber.Handler) Route {
-	fr := r.fiberRouter.Add(method, path, handler...)
+	var fr fiber.Router
+	r.register(func() {
+		fr = r.fiberRouter.Add(method, path, handler...)
+	})
 	route := &route{fr: fr}
 
 	if method == fiber.MethodConnect {
@@ -114,27 +124,37 @@ func (r *router) Add(method, path string, handler ...fiber.Handler) Route {
 }
 
 func (r *router) Static(prefix, root string, config ...fiber.Static) Router {
-	r.fiberRouter.Static(prefix, root, config...)
+	r.register(func() {
+		r.fiberRouter.Static(prefix, root, config...)
+	})
 	return r
 }
 
 func (r *router) Group(prefix string, handlers ...fiber.Handler) Router {
-	rr := r.fiberRouter.Group(prefix, handlers...)
+	var rr fiber.Router
+	r.register(func() {
+		rr = r.fiberRouter.Group(prefix, handlers...)
+	})
 	sr := r.specRouter.Group(prefix)
 
 	return &router{
 		fiberRouter: rr,
 		specRouter:  sr,
+		mu:          r.mu,
 	}
 }
 
 func (r *router) Route(prefix string, fn func(router Router), opts ...option.GroupOption) Router {
-	fr := r.fiberRouter.Group(prefix)
+	var fr fiber.Router
+	r.register(func() {
+		fr = r.fiberRouter.Group(prefix)
+	})
 	sr := r.specRouter.Group(prefix, opts...)
 
 	subRouter := &router{
 		fiberRouter: fr,
 		specRouter:  sr,
+		mu:          r.mu,
 	}
 
 	fn(subRouter)
@@ -147,8 +167,20 @@ func (r *router) With(opts ...option.GroupOption) Router {
 	return r
 }
 
-func (r *router) Validate() error {
-	return r.gen.Validate()
+func (r *router) Validate(opts ...spec.ValidateOption) error {
+	return r.gen.Validate(opts...)
+}
+
+func (r *router) Document() any {
+	return r.gen.Document()
+}
+
+func (r *router) Operations() []spec.Operation {
+	return r.gen.Operations()
+}
+
+func (r *router) Webhook(name, method string, opts ...option.OperationOption) {
+	r.gen.Webhook(name, method, opts...)
 }
 
 func (r *router) GenerateSchema(formats ...string) ([]byte, error) {
@@ -166,3 +198,16 @@ func (r *router) MarshalJSON() ([]byte, error) {
 func (r *router) WriteSchemaTo(path string) error {
 	return r.gen.WriteSchemaTo(path)
 }
+
+func (r *router) RequestValidator(opts ...validator.Option) fiber.Handler {
+	return adaptor.HTTPMiddleware(validator.NewRequestValidator(r.gen, opts...).Middleware)
+}
+
+// register runs fn with the registration lock held, since Fiber does not support
+// concurrent registration.
+func (r *router) register(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn()
+}
@@ -2,10 +2,13 @@ package fiberopenapi_test
 
 import (
 	"flag"
+	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -577,3 +580,143 @@ func TestGeneratorMarshalJSON(t *testing.T) {
 	require.NoError(t, err, "failed to marshal OpenAPI schema to JSON")
 	assert.NotEmpty(t, jsonData, "expected non-empty JSON data")
 }
+
+func TestGenerator_RequestValidator(t *testing.T) {
+	type ListPetsRequest struct {
+		Limit int `query:"limit" minimum:"1"`
+	}
+	app := fiber.New()
+	r := fiberopenapi.NewRouter(app)
+	r.Use(r.RequestValidator())
+	r.Get("/pets", PingHandler).With(
+		option.Request(new(ListPetsRequest)),
+	)
+
+	t.Run("valid request", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/pets?limit=10", nil)
+		res, err := app.Test(req, -1)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/pets?limit=0", nil)
+		res, err := app.Test(req, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), `"name":"limit"`)
+	})
+}
+
+func TestGenerator_Operations(t *testing.T) {
+	r := fiberopenapi.NewRouter(fiber.New())
+	r.Group("/pets").With(option.GroupTags("pets")).
+		Get("/:petId", nil).With(option.Summary("Get pet"))
+
+	ops := r.Operations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, "GET", ops[0].Method)
+	assert.Equal(t, "/pets/{petId}", ops[0].Path)
+	assert.Equal(t, "Get pet", ops[0].Config.Summary)
+	assert.Equal(t, []string{"pets"}, ops[0].Config.Tags)
+	assert.False(t, ops[0].Hidden)
+}
+
+func TestGenerator_Document(t *testing.T) {
+	var transformed any
+	r := fiberopenapi.NewRouter(fiber.New(), option.WithSpecTransformer(func(doc any) error {
+		transformed = doc
+		return nil
+	}))
+	r.Get("/pets", nil).With(option.Summary("List pets"))
+
+	doc := r.Document()
+	require.NotNil(t, doc)
+	assert.Same(t, transformed, doc)
+}
+
+func TestGenerator_LateRoutes(t *testing.T) {
+	app := fiber.New()
+	r := fiberopenapi.NewRouter(app)
+	serveSpec := func() string {
+		req, _ := http.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil)
+		res, err := app.Test(req, -1)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	r.Get("/ping", PingHandler)
+	assert.Contains(t, serveSpec(), "/ping:")
+
+	r.Get("/pong", PingHandler)
+	assert.Contains(t, serveSpec(), "/pong:", "expected route added after the spec was served")
+}
+
+func TestGenerator_PathParams(t *testing.T) {
+	app := fiber.New()
+	r := fiberopenapi.NewRouter(app)
+
+	r.Get("/pets/:id", PingHandler).With(option.Request(new(struct {
+		ID int `path:"petId"`
+	})))
+
+	err := r.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GET /pets/{id}: path parameter \"id\" is not declared")
+	assert.Contains(t, err.Error(), `GET /pets/{id}: field ID declares path parameter "petId", which is not in the path`)
+}
+
+func TestGenerator_ConcurrentRegistration(t *testing.T) {
+	app := fiber.New()
+	r := fiberopenapi.NewRouter(app)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(version string) {
+			defer wg.Done()
+			r.Get(version+"/health", PingHandler)
+			r.Route(version, func(r fiberopenapi.Router) {
+				r.Get("/pets", PingHandler).With(option.Summary("List pets"))
+				r.Post("/orders", PingHandler)
+			}, option.GroupTags("pets"))
+			_, err := r.MarshalYAML()
+			assert.NoError(t, err)
+		}(fmt.Sprintf("/v%d", i))
+	}
+	wg.Wait()
+
+	assert.Len(t, r.Operations(), 30)
+	for i := 0; i < 10; i++ {
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v%d/health", i), nil),
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v%d/pets", i), nil),
+			httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v%d/orders", i), nil),
+		} {
+			res, err := app.Test(req, -1)
+			require.NoError(t, err)
+			_ = res.Body.Close()
+			assert.Equal(t, http.StatusOK, res.StatusCode, req.URL.Path)
+		}
+	}
+}
+
+func TestGenerator_Webhook(t *testing.T) {
+	r := fiberopenapi.NewRouter(fiber.New(), option.WithOpenAPIVersion("3.1.0"))
+	r.Webhook("petCreated", "POST",
+		option.Summary("Pet created"),
+		option.Request(new(struct {
+			ID string `json:"id"`
+		})),
+	)
+
+	schema, err := r.MarshalYAML()
+	require.NoError(t, err, "failed to marshal OpenAPI schema to YAML")
+	assert.Contains(t, string(schema), "webhooks:\n  petCreated:\n    post:", "expected webhook in schema YAML")
+}
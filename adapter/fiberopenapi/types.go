@@ -2,15 +2,29 @@ package fiberopenapi
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/oaswrap/spec"
 	"github.com/oaswrap/spec/option"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 // Generator defines the interface for generating OpenAPI schemas.
 type Generator interface {
 	Router
 
+	// Webhook registers an outgoing webhook for OpenAPI 3.1 documentation.
+	// It adds no route; use option.Request to describe the payload sent to subscribers.
+	Webhook(name, method string, opts ...option.OperationOption)
+
 	// Validate checks for errors at OpenAPI router initialization.
-	Validate() error
+	Validate(opts ...spec.ValidateOption) error
+
+	// Document returns the built OpenAPI document, a *openapi3.Spec or *openapi31.Spec
+	// of github.com/swaggest/openapi-go depending on the OpenAPI version.
+	Document() any
+
+	// Operations returns the registered operations in registration order, with the options
+	// of their groups applied.
+	Operations() []spec.Operation
 
 	// GenerateSchema generates the OpenAPI schema in the specified format.
 	GenerateSchema(format ...string) ([]byte, error)
@@ -21,6 +35,10 @@ type Generator interface {
 
 	// WriteSchemaTo writes the OpenAPI schema to a file.
 	WriteSchemaTo(filePath string) error
+
+	// RequestValidator returns a middleware that validates incoming requests
+	// against the generated OpenAPI schema and rejects invalid ones with 400 Bad Request.
+	RequestValidator(opts ...validator.Option) fiber.Handler
 }
 
 // Router defines the interface for an OpenAPI router.
@@ -2,6 +2,7 @@ package ginopenapi
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/gin-gonic/gin"
 	"github.com/oaswrap/spec"
@@ -10,6 +11,7 @@ import (
 	"github.com/oaswrap/spec/option"
 	"github.com/oaswrap/spec/pkg/mapper"
 	"github.com/oaswrap/spec/pkg/parser"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 // NewGenerator returns a new OpenAPI generator for Gin.
@@ -39,6 +41,7 @@ func NewRouter(ginRouter gin.IRouter, opts ...option.OpenAPIOption) Generator {
 		ginRouter:  ginRouter,
 		specRouter: gen,
 		gen:        gen,
+		mu:         &sync.Mutex{},
 	}
 	if cfg.DisableDocs {
 		return rr
@@ -47,7 +50,7 @@ func NewRouter(ginRouter gin.IRouter, opts ...option.OpenAPIOption) Generator {
 	handler := specui.NewHandler(mapper.SpecUIOpts(gen)...)
 
 	ginRouter.GET(cfg.DocsPath, gin.WrapH(handler.Docs()))
-	ginRouter.GET(cfg.SpecPath, gin.WrapH(handler.Spec()))
+	ginRouter.GET(cfg.SpecPath, gin.WrapH(handler.SpecFunc()))
 
 	return rr
 }
@@ -56,13 +59,17 @@ type router struct {
 	ginRouter  gin.IRouter
 	specRouter spec.Router
 	gen        spec.Generator
+	mu         *sync.Mutex // Serializes registration on the Gin engine.
 }
 
 var _ Generator = &router{}
 
 // Handle registers a new route with the specified method and path, and returns a Route object.
 func (r *router) Handle(method string, path string, handlers ...gin.HandlerFunc) Route {
-	gr := r.ginRouter.Handle(method, path, handlers...)
+	var gr gin.IRoutes
+	r.register(func() {
+		gr = r.ginRouter.Handle(method, path, handlers...)
+	})
 	route := &route{ginRoute: gr}
 
 	if method == http.MethodConnect {
@@ -111,46 +118,60 @@ func (r *router) HEAD(path string, handlers ...gin.HandlerFunc) Route {
 
 // Group creates a new route group with the specified prefix and handlers.
 func (r *router) Group(prefix string, handlers ...gin.HandlerFunc) Router {
-	ginGroup := r.ginRouter.Group(prefix, handlers...)
+	var ginGroup *gin.RouterGroup
+	r.register(func() {
+		ginGroup = r.ginRouter.Group(prefix, handlers...)
+	})
 	specGroup := r.specRouter.Group(prefix)
 
 	return &router{
 		ginRouter:  ginGroup,
 		specRouter: specGroup,
+		mu:         r.mu,
 	}
 }
 
 // Use adds middleware to the router.
 func (r *router) Use(middlewares ...gin.HandlerFunc) Router {
-	r.ginRouter.Use(middlewares...)
+	r.register(func() {
+		r.ginRouter.Use(middlewares...)
+	})
 
 	return r
 }
 
 // StaticFile serves a single file at the specified path.
 func (r *router) StaticFile(path string, filepath string) Router {
-	r.ginRouter.StaticFile(path, filepath)
+	r.register(func() {
+		r.ginRouter.StaticFile(path, filepath)
+	})
 
 	return r
 }
 
 // StaticFileFS serves a single file at the specified path using the provided file system.
 func (r *router) StaticFileFS(path string, filepath string, fs http.FileSystem) Router {
-	r.ginRouter.StaticFileFS(path, filepath, fs)
+	r.register(func() {
+		r.ginRouter.StaticFileFS(path, filepath, fs)
+	})
 
 	return r
 }
 
 // Static serves static files from the specified root directory.
 func (r *router) Static(path string, root string) Router {
-	r.ginRouter.Static(path, root)
+	r.register(func() {
+		r.ginRouter.Static(path, root)
+	})
 
 	return r
 }
 
 // StaticFS serves static files from the specified file system at the given path.
 func (r *router) StaticFS(path string, fs http.FileSystem) Router {
-	r.ginRouter.StaticFS(path, fs)
+	r.register(func() {
+		r.ginRouter.StaticFS(path, fs)
+	})
 
 	return r
 }
@@ -164,8 +185,21 @@ func (r *router) With(opts ...option.GroupOption) Router {
 }
 
 // Validate checks if the OpenAPI specification is valid.
-func (r *router) Validate() error {
-	return r.gen.Validate()
+func (r *router) Validate(opts ...spec.ValidateOption) error {
+	return r.gen.Validate(opts...)
+}
+
+func (r *router) Document() any {
+	return r.gen.Document()
+}
+
+func (r *router) Operations() []spec.Operation {
+	return r.gen.Operations()
+}
+
+// Webhook registers an outgoing webhook in the OpenAPI documentation.
+func (r *router) Webhook(name, method string, opts ...option.OperationOption) {
+	r.gen.Webhook(name, method, opts...)
 }
 
 // GenerateSchema generates the OpenAPI schema in the specified format(s).
@@ -187,3 +221,24 @@ func (r *router) MarshalJSON() ([]byte, error) {
 func (r *router) WriteSchemaTo(filepath string) error {
 	return r.gen.WriteSchemaTo(filepath)
 }
+
+func (r *router) RequestValidator(opts ...validator.Option) gin.HandlerFunc {
+	v := validator.NewRequestValidator(r.gen, opts...)
+	return func(c *gin.Context) {
+		if err := v.Validate(c.Request); err != nil {
+			v.HandleError(c.Writer, c.Request, err)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// register runs fn with the registration lock held, since Gin does not support
+// concurrent registration.
+func (r *router) register(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn()
+}
@@ -4,7 +4,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/oaswrap/spec"
 	"github.com/oaswrap/spec/option"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 // Generator defines an Gin-compatible OpenAPI generator.
@@ -13,8 +15,20 @@ import (
 type Generator interface {
 	Router
 
+	// Webhook registers an outgoing webhook for OpenAPI 3.1 documentation.
+	// It adds no route; use option.Request to describe the payload sent to subscribers.
+	Webhook(name, method string, opts ...option.OperationOption)
+
 	// Validate checks if the OpenAPI specification is valid.
-	Validate() error
+	Validate(opts ...spec.ValidateOption) error
+
+	// Document returns the built OpenAPI document, a *openapi3.Spec or *openapi31.Spec
+	// of github.com/swaggest/openapi-go depending on the OpenAPI version.
+	Document() any
+
+	// Operations returns the registered operations in registration order, with the options
+	// of their groups applied.
+	Operations() []spec.Operation
 
 	// GenerateSchema generates the OpenAPI schema.
 	// Defaults to YAML. Pass "json" to generate JSON.
@@ -29,6 +43,10 @@ type Generator interface {
 	// WriteSchemaTo writes the schema to the given file.
 	// The format is inferred from the file extension.
 	WriteSchemaTo(filepath string) error
+
+	// RequestValidator returns a middleware that validates incoming requests
+	// against the generated OpenAPI schema and rejects invalid ones with 400 Bad Request.
+	RequestValidator(opts ...validator.Option) gin.HandlerFunc
 }
 
 // Router defines an OpenAPI-aware Gin router.
@@ -11,6 +11,7 @@ import (
 	"github.com/oaswrap/spec/adapter/httpopenapi/internal/parser"
 	"github.com/oaswrap/spec/option"
 	"github.com/oaswrap/spec/pkg/mapper"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 type router struct {
@@ -151,8 +152,20 @@ func (r *router) With(opts ...option.GroupOption) Router {
 	return r
 }
 
-func (r *router) Validate() error {
-	return r.gen.Validate()
+func (r *router) Validate(opts ...spec.ValidateOption) error {
+	return r.gen.Validate(opts...)
+}
+
+func (r *router) Document() any {
+	return r.gen.Document()
+}
+
+func (r *router) Operations() []spec.Operation {
+	return r.gen.Operations()
+}
+
+func (r *router) Webhook(name, method string, opts ...option.OperationOption) {
+	r.gen.Webhook(name, method, opts...)
 }
 
 func (r *router) GenerateSchema(formats ...string) ([]byte, error) {
@@ -170,3 +183,7 @@ func (r *router) MarshalJSON() ([]byte, error) {
 func (r *router) WriteSchemaTo(filename string) error {
 	return r.gen.WriteSchemaTo(filename)
 }
+
+func (r *router) RequestValidator(opts ...validator.Option) func(http.Handler) http.Handler {
+	return validator.NewRequestValidator(r.gen, opts...).Middleware
+}
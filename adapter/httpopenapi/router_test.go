@@ -3,10 +3,12 @@ package httpopenapi_test
 import (
 	"encoding/json"
 	"flag"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/oaswrap/spec/adapter/httpopenapi"
@@ -572,3 +574,124 @@ func TestGenerator_WriteSchemaTo(t *testing.T) {
 	assert.NotEmpty(t, schemaData, "expected non-empty schema data")
 	assert.Contains(t, string(schemaData), "operationId: pingHandler", "expected operationId in written schema")
 }
+
+func TestGenerator_RequestValidator(t *testing.T) {
+	type ListPetsRequest struct {
+		Limit int `query:"limit" minimum:"1"`
+	}
+	mux := http.NewServeMux()
+	r := httpopenapi.NewRouter(mux)
+	r.HandleFunc("GET /pets", pingHandler).With(
+		option.Request(new(ListPetsRequest)),
+	)
+	handler := r.RequestValidator()(mux)
+
+	t.Run("valid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pets?limit=10", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "pong")
+	})
+	t.Run("invalid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pets?limit=0", nil)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"name":"limit"`)
+	})
+}
+
+func TestGenerator_Operations(t *testing.T) {
+	r := httpopenapi.NewRouter(http.NewServeMux())
+	r.Group("/pets").With(option.GroupTags("pets")).
+		HandleFunc("GET /{petId}", nil).With(option.Summary("Get pet"))
+
+	ops := r.Operations()
+	require.Len(t, ops, 1)
+	assert.Equal(t, "GET", ops[0].Method)
+	assert.Equal(t, "/pets/{petId}", ops[0].Path)
+	assert.Equal(t, "Get pet", ops[0].Config.Summary)
+	assert.Equal(t, []string{"pets"}, ops[0].Config.Tags)
+	assert.False(t, ops[0].Hidden)
+}
+
+func TestGenerator_Document(t *testing.T) {
+	var transformed any
+	r := httpopenapi.NewRouter(http.NewServeMux(), option.WithSpecTransformer(func(doc any) error {
+		transformed = doc
+		return nil
+	}))
+	r.HandleFunc("GET /pets", nil).With(option.Summary("List pets"))
+
+	doc := r.Document()
+	require.NotNil(t, doc)
+	assert.Same(t, transformed, doc)
+}
+
+func TestGenerator_LateRoutes(t *testing.T) {
+	mux := http.NewServeMux()
+	r := httpopenapi.NewRouter(mux)
+	serveSpec := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/docs/openapi.yaml", nil)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusOK, rec.Code)
+		return rec.Body.String()
+	}
+
+	r.HandleFunc("GET /ping", pingHandler)
+	assert.Contains(t, serveSpec(), "/ping:")
+
+	r.HandleFunc("GET /pong", pingHandler)
+	assert.Contains(t, serveSpec(), "/pong:", "expected route added after the spec was served")
+}
+
+func TestGenerator_ConcurrentRegistration(t *testing.T) {
+	mux := http.NewServeMux()
+	r := httpopenapi.NewRouter(mux)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(version string) {
+			defer wg.Done()
+			r.HandleFunc("GET "+version+"/health", pingHandler)
+			r.Route(version, func(r httpopenapi.Router) {
+				r.With(option.GroupTags("pets"))
+				r.HandleFunc("GET /pets", pingHandler).With(option.Summary("List pets"))
+				r.HandleFunc("POST /orders", pingHandler)
+			})
+			_, err := r.MarshalYAML()
+			assert.NoError(t, err)
+		}(fmt.Sprintf("/v%d", i))
+	}
+	wg.Wait()
+
+	assert.Len(t, r.Operations(), 30)
+	for i := 0; i < 10; i++ {
+		for _, req := range []*http.Request{
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v%d/health", i), nil),
+			httptest.NewRequest(http.MethodGet, fmt.Sprintf("/v%d/pets", i), nil),
+			httptest.NewRequest(http.MethodPost, fmt.Sprintf("/v%d/orders", i), nil),
+		} {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusOK, rec.Code, req.URL.Path)
+		}
+	}
+}
+
+func TestGenerator_Webhook(t *testing.T) {
+	r := httpopenapi.NewRouter(http.NewServeMux(), option.WithOpenAPIVersion("3.1.0"))
+	r.Webhook("petCreated", "POST",
+		option.Summary("Pet created"),
+		option.Request(new(struct {
+			ID string `json:"id"`
+		})),
+	)
+
+	schema, err := r.MarshalYAML()
+	require.NoError(t, err, "failed to marshal OpenAPI schema to YAML")
+	assert.Contains(t, string(schema), "webhooks:\n  petCreated:\n    post:", "expected webhook in schema YAML")
+}
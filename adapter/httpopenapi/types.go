@@ -3,15 +3,29 @@ package httpopenapi
 import (
 	"net/http"
 
+	"github.com/oaswrap/spec"
 	"github.com/oaswrap/spec/option"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 // Generator is an interface for generating OpenAPI documentation for HTTP applications.
 type Generator interface {
 	Router
 
+	// Webhook registers an outgoing webhook for OpenAPI 3.1 documentation.
+	// It adds no route; use option.Request to describe the payload sent to subscribers.
+	Webhook(name, method string, opts ...option.OperationOption)
+
 	// Validate checks if the OpenAPI schema is valid.
-	Validate() error
+	Validate(opts ...spec.ValidateOption) error
+
+	// Document returns the built OpenAPI document, a *openapi3.Spec or *openapi31.Spec
+	// of github.com/swaggest/openapi-go depending on the OpenAPI version.
+	Document() any
+
+	// Operations returns the registered operations in registration order, with the options
+	// of their groups applied.
+	Operations() []spec.Operation
 
 	// GenerateSchema generates the OpenAPI schema in the specified formats.
 	// Supported formats include "yaml", "json", etc.
@@ -26,6 +40,10 @@ type Generator interface {
 
 	// WriteSchemaTo writes the OpenAPI schema to a file in the specified format.
 	WriteSchemaTo(filename string) error
+
+	// RequestValidator returns a middleware that validates incoming requests
+	// against the generated OpenAPI schema and rejects invalid ones with 400 Bad Request.
+	RequestValidator(opts ...validator.Option) func(http.Handler) http.Handler
 }
 
 // Router is an interface for handling HTTP routes with OpenAPI support.
@@ -2,6 +2,8 @@ package httprouteropenapi
 
 import (
 	"net/http"
+	"slices"
+	"sync"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/oaswrap/spec"
@@ -11,6 +13,7 @@ import (
 	"github.com/oaswrap/spec/pkg/mapper"
 	"github.com/oaswrap/spec/pkg/parser"
 	"github.com/oaswrap/spec/pkg/util"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 // NewRouter creates a new router with the given HTTP router and options.
@@ -34,6 +37,7 @@ func NewGenerator(httpRouter *httprouter.Router, opts ...option.OpenAPIOption) G
 		router:     httpRouter,
 		specRouter: gen,
 		gen:        gen,
+		mu:         &sync.Mutex{},
 	}
 
 	cfg := gen.Config()
@@ -44,7 +48,7 @@ func NewGenerator(httpRouter *httprouter.Router, opts ...option.OpenAPIOption) G
 	handler := specui.NewHandler(mapper.SpecUIOpts(gen)...)
 
 	httpRouter.Handler(http.MethodGet, cfg.DocsPath, handler.Docs())
-	httpRouter.Handler(http.MethodGet, cfg.SpecPath, handler.Spec())
+	httpRouter.Handler(http.MethodGet, cfg.SpecPath, handler.SpecFunc())
 
 	return r
 }
@@ -56,6 +60,7 @@ type router struct {
 
 	specRouter spec.Router
 	gen        spec.Generator
+	mu         *sync.Mutex // Serializes registration on the httprouter.Router.
 }
 
 func (r *router) wrapHandler(h httprouter.Handle) httprouter.Handle {
@@ -84,7 +89,9 @@ func (r *router) Handle(method, path string, handle httprouter.Handle) Route {
 		handle = r.wrapHandler(handle)
 	}
 	path = r.pathOf(path)
-	r.router.Handle(method, path, handle)
+	r.register(func() {
+		r.router.Handle(method, path, handle)
+	})
 	rr := &route{}
 	if method != http.MethodConnect {
 		rr.specRoute = r.specRouter.Add(method, path)
@@ -99,7 +106,9 @@ func (r *router) Handler(method, path string, handler http.Handler) Route {
 			handler = r.middlewares[i](handler)
 		}
 	}
-	r.router.Handler(method, r.pathOf(path), handler)
+	r.register(func() {
+		r.router.Handler(method, r.pathOf(path), handler)
+	})
 	rr := &route{}
 	if method != http.MethodConnect {
 		rr.specRoute = r.specRouter.Add(method, path)
@@ -149,15 +158,18 @@ func (r *router) Lookup(method, path string) (httprouter.Handle, httprouter.Para
 }
 
 func (r *router) ServeFiles(path string, root http.FileSystem) {
-	r.router.ServeFiles(path, root)
+	r.register(func() {
+		r.router.ServeFiles(path, root)
+	})
 }
 
 func (r *router) Group(prefix string, middlewares ...func(http.Handler) http.Handler) Router {
 	group := &router{
 		router:      r.router,
-		middlewares: append(r.middlewares, middlewares...),
+		middlewares: append(slices.Clone(r.middlewares), middlewares...),
 		specRouter:  r.specRouter.Group(prefix),
 		prefix:      r.pathOf(prefix),
+		mu:          r.mu,
 	}
 	return group
 }
@@ -179,10 +191,35 @@ func (r *router) MarshalYAML() ([]byte, error) {
 	return r.gen.MarshalYAML()
 }
 
-func (r *router) Validate() error {
-	return r.gen.Validate()
+func (r *router) Validate(opts ...spec.ValidateOption) error {
+	return r.gen.Validate(opts...)
+}
+
+func (r *router) Document() any {
+	return r.gen.Document()
+}
+
+func (r *router) Operations() []spec.Operation {
+	return r.gen.Operations()
+}
+
+func (r *router) Webhook(name, method string, opts ...option.OperationOption) {
+	r.gen.Webhook(name, method, opts...)
 }
 
 func (r *router) WriteSchemaTo(path string) error {
 	return r.gen.WriteSchemaTo(path)
 }
+
+func (r *router) RequestValidator(opts ...validator.Option) func(http.Handler) http.Handler {
+	return validator.NewRequestValidator(r.gen, opts...).Middleware
+}
+
+// register runs fn with the registration lock held, since httprouter does not support
+// concurrent registration.
+func (r *router) register(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn()
+}
@@ -2,6 +2,7 @@ package muxopenapi
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/mux"
 	"github.com/oaswrap/spec"
@@ -12,6 +13,7 @@ type route struct {
 	muxRoute   *mux.Route
 	specRoute  spec.Route
 	specRouter spec.Router
+	mu         *sync.Mutex
 
 	pathPrefix string
 }
@@ -59,6 +61,10 @@ func (r *route) Methods(methods ...string) Route {
 }
 
 func (r *route) Name(name string) Route {
+	// Names are registered in a map shared by the routers of the tree.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	r.muxRoute.Name(name)
 	return r
 }
@@ -93,6 +99,7 @@ func (r *route) Subrouter(opts ...option.GroupOption) Router {
 	return &router{
 		muxRouter:  r.muxRoute.Subrouter(),
 		specRouter: r.specRouter.Group(r.pathPrefix, opts...),
+		mu:         r.mu,
 	}
 }
 
@@ -2,6 +2,7 @@ package muxopenapi
 
 import (
 	"net/http"
+	"sync"
 
 	"github.com/gorilla/mux"
 	"github.com/oaswrap/spec"
@@ -9,12 +10,14 @@ import (
 	"github.com/oaswrap/spec/adapter/muxopenapi/internal/constant"
 	"github.com/oaswrap/spec/option"
 	"github.com/oaswrap/spec/pkg/mapper"
+	"github.com/oaswrap/spec/pkg/validator"
 )
 
 type router struct {
 	muxRouter  *mux.Router
 	specRouter spec.Router
 	gen        spec.Generator
+	mu         *sync.Mutex // Serializes registration on the mux routers of the tree.
 }
 
 var _ Generator = (*router)(nil)
@@ -43,6 +46,7 @@ func NewGenerator(mux *mux.Router, opts ...option.OpenAPIOption) Generator {
 		muxRouter:  mux,
 		specRouter: gen,
 		gen:        gen,
+		mu:         &sync.Mutex{},
 	}
 	cfg := gen.Config()
 	if cfg.DisableDocs {
@@ -52,26 +56,34 @@ func NewGenerator(mux *mux.Router, opts ...option.OpenAPIOption) Generator {
 	handler := specui.NewHandler(mapper.SpecUIOpts(gen)...)
 
 	mux.Handle(cfg.DocsPath, handler.Docs()).Methods(http.MethodGet)
-	mux.Handle(cfg.SpecPath, handler.Spec()).Methods(http.MethodGet)
+	mux.Handle(cfg.SpecPath, handler.SpecFunc()).Methods(http.MethodGet)
 
 	return rr
 }
 
 func (r *router) Get(name string) Route {
-	muxRoute := r.muxRouter.Get(name)
+	var muxRoute *mux.Route
+	r.register(func() {
+		muxRoute = r.muxRouter.Get(name)
+	})
 
 	return &route{
 		muxRoute:   muxRoute,
 		specRouter: r.specRouter,
+		mu:         r.mu,
 	}
 }
 
 func (r *router) GetRoute(name string) Route {
-	muxRoute := r.muxRouter.GetRoute(name)
+	var muxRoute *mux.Route
+	r.register(func() {
+		muxRoute = r.muxRouter.GetRoute(name)
+	})
 
 	return &route{
 		muxRoute:   muxRoute,
 		specRouter: r.specRouter,
+		mu:         r.mu,
 	}
 }
 
@@ -100,10 +112,16 @@ func (r *router) Name(name string) Route {
 }
 
 func (r *router) NewRoute() Route {
+	var muxRoute *mux.Route
+	r.register(func() {
+		muxRoute = r.muxRouter.NewRoute()
+	})
+
 	return &route{
-		muxRoute:   r.muxRouter.NewRoute(),
+		muxRoute:   muxRoute,
 		specRoute:  r.specRouter.NewRoute(),
 		specRouter: r.specRouter,
+		mu:         r.mu,
 	}
 }
 
@@ -128,22 +146,30 @@ func (r *router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 }
 
 func (r *router) SkipClean(value bool) Router {
-	r.muxRouter.SkipClean(value)
+	r.register(func() {
+		r.muxRouter.SkipClean(value)
+	})
 	return r
 }
 
 func (r *router) StrictSlash(value bool) Router {
-	r.muxRouter.StrictSlash(value)
+	r.register(func() {
+		r.muxRouter.StrictSlash(value)
+	})
 	return r
 }
 
 func (r *router) Use(middlewares ...mux.MiddlewareFunc) Router {
-	r.muxRouter.Use(middlewares...)
+	r.register(func() {
+		r.muxRouter.Use(middlewares...)
+	})
 	return r
 }
 
 func (r *router) UseEncodedPath() Router {
-	r.muxRouter.UseEncodedPath()
+	r.register(func() {
+		r.muxRouter.UseEncodedPath()
+	})
 	return r
 }
 
@@ -164,10 +190,35 @@ func (r *router) MarshalYAML() ([]byte, error) {
 	return r.gen.MarshalYAML()
 }
 
-func (r *router) Validate() error {
-	return r.gen.Validate()
+func (r *router) Validate(opts ...spec.ValidateOption) error {
+	return r.gen.Validate(opts...)
+}
+
+func (r *router) Document() any {
+	return r.gen.Document()
+}
+
+func (r *router) Operations() []spec.Operation {
+	return r.gen.Operations()
+}
+
+func (r *router) Webhook(name, method string, opts ...option.OperationOption) {
+	r.gen.Webhook(name, method, opts...)
 }
 
 func (r *router) WriteSchemaTo(path string) error {
 	return r.gen.WriteSchemaTo(path)
 }
+
+func (r *router) RequestValidator(opts ...validator.Option) mux.MiddlewareFunc {
+	return validator.NewRequestValidator(r.gen, opts...).Middleware
+}
+
+// register runs fn with the registration lock held, since mux routers do not support
+// concurrent registration.
+func (r *router) register(fn func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	fn()
+}
@@ -0,0 +1,132 @@
+package spec
+
+import (
+	"fmt"
+
+	"github.com/oaswrap/spec/internal/document"
+)
+
+// checkSpec runs the checks that need the complete specification, such as references
+// between operations, on the marshaled spec data.
+func checkSpec(data []byte) []error {
+	doc, err := document.Parse(data)
+	if err != nil {
+		return []error{fmt.Errorf("failed to parse generated spec: %w", err)}
+	}
+	ops := allOperations(doc)
+
+	var errs []error
+	errs = append(errs, checkOperationIDs(ops)...)
+	errs = append(errs, checkLinks(doc, ops)...)
+	errs = append(errs, checkSecurity(doc, ops)...)
+	return errs
+}
+
+// allOperations returns the operations under paths and webhooks, followed by their callbacks.
+func allOperations(doc *document.Document) []document.Operation {
+	var ops []document.Operation
+	ops = append(ops, doc.Operations()...)
+	ops = append(ops, doc.Webhooks()...)
+	for _, op := range ops {
+		ops = append(ops, doc.Callbacks(op)...)
+	}
+	return ops
+}
+
+// checkOperationIDs reports operationIds used by more than one operation.
+func checkOperationIDs(ops []document.Operation) []error {
+	first := map[string]document.Operation{}
+
+	var errs []error
+	for _, op := range ops {
+		id := document.String(op.Node, "operationId")
+		if id == "" {
+			continue
+		}
+		if other, ok := first[id]; ok {
+			errs = append(errs, fmt.Errorf("operationId %q of %s %s is already used by %s %s",
+				id, op.Method, op.Path, other.Method, other.Path))
+			continue
+		}
+		first[id] = op
+	}
+	return errs
+}
+
+// checkLinks reports response links to an operationId that is not defined.
+func checkLinks(doc *document.Document, ops []document.Operation) []error {
+	ids := map[string]bool{}
+	for _, op := range ops {
+		if id := document.String(op.Node, "operationId"); id != "" {
+			ids[id] = true
+		}
+	}
+
+	var errs []error
+	for _, op := range ops {
+		responses := document.Map(op.Node, "responses")
+		for _, status := range document.SortedKeys(responses) {
+			resp, respPtr := doc.Resolve(document.Map(responses, status), document.Append(op.Pointer, "responses", status))
+			links := document.Map(resp, "links")
+			for _, name := range document.SortedKeys(links) {
+				link, _ := doc.Resolve(document.Map(links, name), document.Append(respPtr, "links", name))
+				if id := document.String(link, "operationId"); id != "" && !ids[id] {
+					errs = append(errs, fmt.Errorf("link %q in response %s of %s %s refers to undefined operationId %q",
+						name, status, op.Method, op.Path, id))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// checkSecurity reports security requirements that use a security scheme that is not defined,
+// or OAuth2 scopes that are not declared by any flow of the scheme.
+func checkSecurity(doc *document.Document, ops []document.Operation) []error {
+	schemes := document.Map(document.Map(doc.Raw(), "components"), "securitySchemes")
+
+	var errs []error
+	for _, op := range ops {
+		for _, item := range document.Slice(op.Node, "security") {
+			requirement, _ := item.(map[string]any)
+			errs = append(errs, checkSecurityRequirement(doc, schemes, op, requirement)...)
+		}
+	}
+	return errs
+}
+
+func checkSecurityRequirement(
+	doc *document.Document, schemes map[string]any, op document.Operation, requirement map[string]any,
+) []error {
+	var errs []error
+	for _, name := range document.SortedKeys(requirement) {
+		scheme, _ := doc.Resolve(document.Map(schemes, name), document.Pointer("components", "securitySchemes", name))
+		if scheme == nil {
+			errs = append(errs, fmt.Errorf("%s %s requires undefined security scheme %q", op.Method, op.Path, name))
+			continue
+		}
+		if document.String(scheme, "type") != "oauth2" {
+			continue
+		}
+		scopes := oauth2Scopes(scheme)
+		for _, scope := range document.Slice(requirement, name) {
+			if scope, _ := scope.(string); !scopes[scope] {
+				errs = append(errs, fmt.Errorf("%s %s requires scope %q, which security scheme %q does not declare",
+					op.Method, op.Path, scope, name))
+			}
+		}
+	}
+	return errs
+}
+
+// oauth2Scopes returns the scopes declared by the flows of an OAuth2 security scheme.
+func oauth2Scopes(scheme map[string]any) map[string]bool {
+	scopes := map[string]bool{}
+	flows := document.Map(scheme, "flows")
+	for _, flow := range document.SortedKeys(flows) {
+		for scope := range document.Map(document.Map(flows, flow), "scopes") {
+			scopes[scope] = true
+		}
+	}
+	return scopes
+}
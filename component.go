@@ -0,0 +1,207 @@
+package spec
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/oaswrap/spec/internal/document"
+	specopenapi "github.com/oaswrap/spec/openapi"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
+)
+
+func componentRef(section, name string) string {
+	return "#/components/" + section + "/" + name
+}
+
+// responseStatus returns the key a response is documented under, following the rules
+// of the underlying reflector: zero is 200 and 1 to 5 are status ranges such as "4XX".
+func responseStatus(httpStatus int, isDefault bool) string {
+	switch {
+	case isDefault:
+		return "default"
+	case httpStatus == 0:
+		return "200"
+	case httpStatus < 6:
+		return strconv.Itoa(httpStatus) + "XX"
+	default:
+		return strconv.Itoa(httpStatus)
+	}
+}
+
+// componentResponseStatus returns the status shared by the media types of a response component.
+func componentResponseStatus(units []*specopenapi.ContentUnit) (string, error) {
+	status := responseStatus(units[0].HTTPStatus, units[0].IsDefault)
+	for _, cu := range units[1:] {
+		if other := responseStatus(cu.HTTPStatus, cu.IsDefault); other != status {
+			return "", fmt.Errorf("media types are declared under statuses %s and %s", status, other)
+		}
+	}
+	return status, nil
+}
+
+// unreferencedSchemas returns the names of the schema components of a marshaled spec that
+// are not in existing and that neither the rest of the spec nor an existing schema
+// references, directly or through other schemas.
+func unreferencedSchemas(data []byte, existing map[string]bool) ([]string, error) {
+	doc, err := document.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec for pruning schemas: %w", err)
+	}
+	raw := doc.Raw()
+	var roots []any
+	for key, value := range raw {
+		if key != "components" {
+			roots = append(roots, value)
+		}
+	}
+	components := document.Map(raw, "components")
+	for section, value := range components {
+		if section != "schemas" {
+			roots = append(roots, value)
+		}
+	}
+	schemas := document.Map(components, "schemas")
+	for name, schema := range schemas {
+		if existing[name] {
+			roots = append(roots, schema)
+		}
+	}
+	reached := doc.ReachableComponents(roots...)
+	var unused []string
+	for _, name := range document.SortedKeys(schemas) {
+		if !existing[name] && !reached[document.Pointer("components", "schemas", name)] {
+			unused = append(unused, name)
+		}
+	}
+	return unused, nil
+}
+
+// operationRefs holds the references to components of an operation.
+type operationRefs struct {
+	parameters  []string
+	requestBody string
+	responses   map[string]string // status -> reference
+}
+
+func (refs operationRefs) empty() bool {
+	return len(refs.parameters) == 0 && refs.requestBody == "" && len(refs.responses) == 0
+}
+
+// resolveRefs checks that the components referenced by the operation are defined.
+func (oc *operationContextImpl) resolveRefs() (operationRefs, error) {
+	cfg := oc.cfg
+	refs := operationRefs{responses: map[string]string{}}
+	for _, name := range cfg.ParameterRefs {
+		if _, ok := oc.components.Parameters[name]; !ok {
+			return refs, fmt.Errorf("parameter component %q is not defined", name)
+		}
+		refs.parameters = append(refs.parameters, componentRef("parameters", name))
+	}
+	if name := cfg.RequestBodyRef; name != "" {
+		if _, ok := oc.components.RequestBodies[name]; !ok {
+			return refs, fmt.Errorf("request body component %q is not defined", name)
+		}
+		refs.requestBody = componentRef("requestBodies", name)
+	}
+
+	inline := map[string]bool{}
+	for _, resp := range cfg.Responses {
+		inline[responseStatus(resp.HTTPStatus, resp.IsDefault)] = true
+	}
+	for _, ref := range cfg.ResponseRefs {
+		units, ok := oc.components.Responses[ref.Name]
+		if !ok {
+			return refs, fmt.Errorf("response component %q is not defined", ref.Name)
+		}
+		status := responseStatus(units[0].HTTPStatus, units[0].IsDefault)
+		if ref.HTTPStatus != 0 {
+			status = responseStatus(ref.HTTPStatus, false)
+		}
+		if inline[status] {
+			return refs, fmt.Errorf("response %s is defined both inline and by reference to %q", status, ref.Name)
+		}
+		if _, exists := refs.responses[status]; exists {
+			return refs, fmt.Errorf("response %s references more than one component", status)
+		}
+		refs.responses[status] = componentRef("responses", ref.Name)
+	}
+	return refs, nil
+}
+
+// addRefs adds the references to components of the operation.
+func (oc *operationContextImpl) addRefs(method, path string) error {
+	refs, err := oc.resolveRefs()
+	if err != nil || refs.empty() {
+		return err
+	}
+	oc.applyRefs(refs)
+	oc.logger.LogOp(method, path, "add component references",
+		fmt.Sprintf("parameters: %v, request body: %q, responses: %v", refs.parameters, refs.requestBody, refs.responses))
+	return nil
+}
+
+// applyRefs adds the references to the operation before its structures are reflected.
+func (oc *operationContextImpl) applyRefs(refs operationRefs) {
+	switch op := oc.op.(type) {
+	case openapi3.OperationExposer:
+		applyRefs3(op.Operation(), refs)
+	case openapi31.OperationExposer:
+		applyRefs31(op.Operation(), refs)
+	}
+}
+
+func applyRefs3(o *openapi3.Operation, refs operationRefs) {
+	for _, ref := range refs.parameters {
+		o.Parameters = append(o.Parameters, openapi3.ParameterOrRef{
+			ParameterReference: &openapi3.ParameterReference{Ref: ref},
+		})
+	}
+	if refs.requestBody != "" {
+		o.RequestBody = &openapi3.RequestBodyOrRef{
+			RequestBodyReference: &openapi3.RequestBodyReference{Ref: refs.requestBody},
+		}
+	}
+	for _, status := range sortedKeys(refs.responses) {
+		resp := openapi3.ResponseOrRef{ResponseReference: &openapi3.ResponseReference{Ref: refs.responses[status]}}
+		if status == "default" {
+			o.Responses.Default = &resp
+			continue
+		}
+		o.Responses.WithMapOfResponseOrRefValuesItem(status, resp)
+	}
+}
+
+func applyRefs31(o *openapi31.Operation, refs operationRefs) {
+	for _, ref := range refs.parameters {
+		o.Parameters = append(o.Parameters, openapi31.ParameterOrReference{
+			Reference: &openapi31.Reference{Ref: ref},
+		})
+	}
+	if refs.requestBody != "" {
+		o.RequestBody = &openapi31.RequestBodyOrReference{
+			Reference: &openapi31.Reference{Ref: refs.requestBody},
+		}
+	}
+	for _, status := range sortedKeys(refs.responses) {
+		resp := openapi31.ResponseOrReference{Reference: &openapi31.Reference{Ref: refs.responses[status]}}
+		if status == "default" {
+			o.ResponsesEns().Default = &resp
+			continue
+		}
+		o.ResponsesEns().WithMapOfResponseOrReferenceValuesItem(status, resp)
+	}
+}
+
+// headerRefs checks that the header components referenced by a response are defined
+// and returns the references by header name.
+func (oc *operationContextImpl) headerRefs(cu *specopenapi.ContentUnit) (map[string]string, error) {
+	refs := make(map[string]string, len(cu.HeaderRefs))
+	for name, component := range cu.HeaderRefs {
+		if _, ok := oc.components.Headers[component]; !ok {
+			return nil, fmt.Errorf("header component %q is not defined", component)
+		}
+		refs[name] = componentRef("headers", component)
+	}
+	return refs, nil
+}
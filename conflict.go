@@ -0,0 +1,71 @@
+package spec
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec/openapi"
+	"github.com/oaswrap/spec/option"
+)
+
+// reTemplateParam matches the parameters of a path template, including the regular
+// expression that gorilla/mux style patterns may add to them.
+var reTemplateParam = regexp.MustCompile(`{([^}:]+)(:[^}]+)?}`)
+
+// registration is an operation added to the spec, as it was registered.
+type registration struct {
+	method      string
+	path        string
+	operationID string
+}
+
+func (r registration) String() string {
+	if r.operationID == "" {
+		return r.method + " " + r.path
+	}
+	return r.method + " " + r.path + " (operationId " + strconv.Quote(r.operationID) + ")"
+}
+
+// routeTable records the operations added to the spec to report the routes that collide.
+//
+// OpenAPI identifies an operation by its method and path template, regardless of the
+// names of the path parameters, so /users/{id} and /users/{userId} are the same path.
+type routeTable struct {
+	idFunc openapi.OperationIDFunc
+	routes map[string]tableEntry // method and path template -> first registration
+}
+
+type tableEntry struct {
+	reg  registration
+	path string // Path as documented.
+}
+
+func newRouteTable(idFunc openapi.OperationIDFunc) *routeTable {
+	return &routeTable{idFunc: idFunc, routes: map[string]tableEntry{}}
+}
+
+// add records an operation registered with the given path and documented under the parsed
+// path, with the configuration of its options. It returns an error naming both
+// registrations if an earlier one uses the same method and path template.
+//
+// Hidden operations are not documented, so they are not added to the spec and never
+// reach the table.
+func (t *routeTable) add(method, registered, path string, cfg *option.OperationConfig) error {
+	method = strings.ToUpper(method)
+	setOperationID(t.idFunc, cfg, method, path)
+	reg := registration{method: method, path: registered, operationID: cfg.OperationID}
+
+	path = reTemplateParam.ReplaceAllString(path, "{$1}")
+	key := method + " " + reTemplateParam.ReplaceAllString(path, "{}")
+	first, ok := t.routes[key]
+	if !ok {
+		t.routes[key] = tableEntry{reg: reg, path: path}
+		return nil
+	}
+	if first.path == path {
+		return fmt.Errorf("route %s conflicts with %s: same method and path", reg, first.reg)
+	}
+	return fmt.Errorf("route %s conflicts with %s: paths differ only in parameter names", reg, first.reg)
+}
@@ -0,0 +1,104 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/oaswrap/spec/internal/mapper"
+	specopenapi "github.com/oaswrap/spec/openapi"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
+)
+
+// contentExamples holds the examples of a content unit, converted to plain JSON values.
+type contentExamples struct {
+	example    any
+	hasExample bool
+	examples   map[string]specopenapi.Example
+	mediaTypes []string // media types of the content unit the examples belong to
+}
+
+// newContentExamples converts the examples of cu with the JSON rules used for the schema,
+// so struct values honor their `json` tags.
+func newContentExamples(cu *specopenapi.ContentUnit) (contentExamples, error) {
+	ce := contentExamples{mediaTypes: contentMediaTypes(cu)}
+	if cu.Example != nil {
+		value, err := exampleValue(cu.Example)
+		if err != nil {
+			return ce, fmt.Errorf("example: %w", err)
+		}
+		ce.example, ce.hasExample = value, true
+	}
+	if len(cu.Examples) > 0 {
+		ce.examples = make(map[string]specopenapi.Example, len(cu.Examples))
+		for name, example := range cu.Examples {
+			if example.Value != nil {
+				value, err := exampleValue(example.Value)
+				if err != nil {
+					return ce, fmt.Errorf("example %q: %w", name, err)
+				}
+				example.Value = value
+			}
+			ce.examples[name] = example
+		}
+	}
+	return ce, nil
+}
+
+func (ce contentExamples) empty() bool {
+	return !ce.hasExample && len(ce.examples) == 0
+}
+
+func (ce contentExamples) customizeResponse(cor openapi.ContentOrReference) {
+	switch v := cor.(type) {
+	case *openapi3.ResponseOrRef:
+		ce.oas3(v.Response.Content)
+	case *openapi31.ResponseOrReference:
+		ce.oas31(v.Response.Content)
+	}
+}
+
+func (ce contentExamples) oas3(content map[string]openapi3.MediaType) {
+	for _, contentType := range ce.mediaTypes {
+		mediaType, ok := content[contentType]
+		if !ok {
+			continue
+		}
+		if ce.hasExample {
+			mediaType.WithExample(ce.example)
+		}
+		if len(ce.examples) > 0 {
+			mediaType.WithExamples(mapper.OAS3Examples(ce.examples))
+		}
+		content[contentType] = mediaType
+	}
+}
+
+func (ce contentExamples) oas31(content map[string]openapi31.MediaType) {
+	for _, contentType := range ce.mediaTypes {
+		mediaType, ok := content[contentType]
+		if !ok {
+			continue
+		}
+		if ce.hasExample {
+			mediaType.WithExample(ce.example)
+		}
+		if len(ce.examples) > 0 {
+			mediaType.WithExamples(mapper.OAS31Examples(ce.examples))
+		}
+		content[contentType] = mediaType
+	}
+}
+
+func exampleValue(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result any
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,236 @@
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec/internal/document"
+	"github.com/oaswrap/spec/openapi"
+)
+
+// namedFragment is a decoded fragment with the name used in error messages.
+type namedFragment struct {
+	name string
+	doc  map[string]any
+}
+
+// mergeFragments merges fragments into the marshaled spec data.
+//
+// It returns the merged JSON document together with every load error and conflict found.
+// Conflicting values are skipped, so the generated specification always wins.
+func mergeFragments(data []byte, version string, fragments []openapi.Fragment) ([]byte, []error) {
+	target, err := document.Decode(data)
+	if err != nil {
+		return nil, []error{fmt.Errorf("failed to decode generated spec: %w", err)}
+	}
+
+	loaded, errs := loadFragments(fragments)
+	for _, fragment := range loaded {
+		m := &fragmentMerger{name: fragment.name, version: version}
+		m.merge(target, fragment.doc)
+		errs = append(errs, m.errs...)
+	}
+
+	merged, err := json.Marshal(target)
+	if err != nil {
+		return nil, append(errs, fmt.Errorf("failed to encode merged spec: %w", err))
+	}
+	return merged, errs
+}
+
+func loadFragments(fragments []openapi.Fragment) ([]namedFragment, []error) {
+	var (
+		loaded []namedFragment
+		errs   []error
+	)
+	add := func(name string, data []byte) {
+		doc, err := document.Decode(data)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fragment %s: %w", name, err))
+			return
+		}
+		loaded = append(loaded, namedFragment{name: name, doc: doc})
+	}
+
+	for i, fragment := range fragments {
+		if fragment.FS == nil {
+			add("#"+strconv.Itoa(i+1), fragment.Data)
+			continue
+		}
+		matches, err := fs.Glob(fragment.FS, fragment.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("fragment %s: %w", fragment.Pattern, err))
+			continue
+		}
+		if len(matches) == 0 {
+			errs = append(errs, fmt.Errorf("fragment %s: no files match the pattern", fragment.Pattern))
+			continue
+		}
+		for _, name := range matches {
+			data, err := fs.ReadFile(fragment.FS, name)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("fragment %s: %w", name, err))
+				continue
+			}
+			add(name, data)
+		}
+	}
+	return loaded, errs
+}
+
+type fragmentMerger struct {
+	name    string
+	version string
+	errs    []error
+}
+
+func (m *fragmentMerger) conflict(format string, args ...any) {
+	m.errs = append(m.errs, fmt.Errorf("fragment %s: %s", m.name, fmt.Sprintf(format, args...)))
+}
+
+func (m *fragmentMerger) merge(target, fragment map[string]any) {
+	for _, key := range document.SortedKeys(fragment) {
+		value := fragment[key]
+		switch key {
+		case "openapi":
+			if version, _ := value.(string); !sameMinorVersion(version, m.version) {
+				m.conflict("openapi version %q does not match %q", version, m.version)
+			}
+		case "paths", "webhooks":
+			m.mergePaths(key, ensureMap(target, key), asMap(value))
+		case "components":
+			m.mergeComponents(ensureMap(target, key), asMap(value))
+		case "tags":
+			target[key] = m.mergeList(key, asSlice(target[key]), asSlice(value), "name")
+		case "servers":
+			target[key] = m.mergeList(key, asSlice(target[key]), asSlice(value), "url")
+		case "security":
+			target[key] = m.mergeList(key, asSlice(target[key]), asSlice(value), "")
+		default:
+			m.mergeValue(target, key, value, document.Pointer(key))
+		}
+	}
+}
+
+// mergePaths merges path items. Operations are atomic: the same method and path
+// defined on both sides is a conflict, while other path item fields are merged.
+func (m *fragmentMerger) mergePaths(section string, target, paths map[string]any) {
+	for _, path := range document.SortedKeys(paths) {
+		item := asMap(paths[path])
+		existing, ok := target[path].(map[string]any)
+		if !ok {
+			target[path] = item
+			continue
+		}
+		for _, key := range document.SortedKeys(item) {
+			if !slices.Contains(document.Methods, key) {
+				m.mergeValue(existing, key, item[key], document.Pointer(section, path, key))
+				continue
+			}
+			if _, exists := existing[key]; exists {
+				m.conflict("operation %s %s is already defined", strings.ToUpper(key), path)
+				continue
+			}
+			existing[key] = item[key]
+		}
+	}
+}
+
+// mergeComponents merges reusable components. Components are atomic: the same name
+// may only be defined twice if both definitions are identical.
+func (m *fragmentMerger) mergeComponents(target, components map[string]any) {
+	for _, section := range document.SortedKeys(components) {
+		entries, ok := components[section].(map[string]any)
+		if !ok {
+			m.mergeValue(target, section, components[section], document.Pointer("components", section))
+			continue
+		}
+		existing := ensureMap(target, section)
+		for _, name := range document.SortedKeys(entries) {
+			current, exists := existing[name]
+			if !exists {
+				existing[name] = entries[name]
+				continue
+			}
+			if !reflect.DeepEqual(current, entries[name]) {
+				m.conflict("component %s %q is already defined", section, name)
+			}
+		}
+	}
+}
+
+// mergeList appends the items of list that are not in target yet. Items are matched by
+// the key field, or by value when key is empty; a matched item must be identical.
+func (m *fragmentMerger) mergeList(section string, target, list []any, key string) []any {
+	for _, item := range list {
+		index := slices.IndexFunc(target, func(existing any) bool {
+			if key == "" {
+				return reflect.DeepEqual(existing, item)
+			}
+			return asMap(existing)[key] == asMap(item)[key]
+		})
+		if index < 0 {
+			target = append(target, item)
+			continue
+		}
+		if !reflect.DeepEqual(target[index], item) {
+			m.conflict("%s entry %q is already defined", section, asMap(item)[key])
+		}
+	}
+	return target
+}
+
+// mergeValue merges objects recursively and requires any other value to be identical.
+func (m *fragmentMerger) mergeValue(target map[string]any, key string, value any, pointer string) {
+	current, exists := target[key]
+	if !exists {
+		target[key] = value
+		return
+	}
+	currentMap, currentIsMap := current.(map[string]any)
+	valueMap, valueIsMap := value.(map[string]any)
+	if currentIsMap && valueIsMap {
+		for _, k := range document.SortedKeys(valueMap) {
+			m.mergeValue(currentMap, k, valueMap[k], document.Append(pointer, k))
+		}
+		return
+	}
+	if !reflect.DeepEqual(current, value) {
+		m.conflict("%s is already defined", pointer)
+	}
+}
+
+func sameMinorVersion(a, b string) bool {
+	minor := func(v string) string {
+		parts := strings.SplitN(v, ".", 3)
+		if len(parts) < 2 {
+			return v
+		}
+		return parts[0] + "." + parts[1]
+	}
+	return minor(a) == minor(b)
+}
+
+func ensureMap(node map[string]any, key string) map[string]any {
+	m, ok := node[key].(map[string]any)
+	if !ok {
+		m = map[string]any{}
+		node[key] = m
+	}
+	return m
+}
+
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
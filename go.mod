@@ -5,6 +5,7 @@ go 1.21
 require (
 	github.com/google/go-cmp v0.7.0
 	github.com/oaswrap/spec-ui v0.1.4
+	github.com/santhosh-tekuri/jsonschema/v5 v5.3.1
 	github.com/stretchr/testify v1.11.1
 	github.com/swaggest/jsonschema-go v0.3.78
 	github.com/swaggest/openapi-go v0.2.60
@@ -0,0 +1,139 @@
+package spec
+
+import (
+	specopenapi "github.com/oaswrap/spec/openapi"
+	"github.com/swaggest/jsonschema-go"
+	"github.com/swaggest/openapi-go"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
+)
+
+// responseHeader is a response header declared with option.ResponseHeader or option.ResponseHeaders.
+type responseHeader struct {
+	name        string
+	description string
+	required    bool
+	deprecated  bool
+	schema      jsonschema.Schema
+	schemaMap   map[string]any // schema converted for OpenAPI 3.1
+}
+
+// reflectResponseHeaders reflects the schemas of the headers declared on a response.
+func reflectResponseHeaders(r *jsonschema.Reflector, cu *specopenapi.ContentUnit) ([]responseHeader, error) {
+	var headers []responseHeader
+	for _, structure := range cu.HeaderStructures {
+		_, err := r.Reflect(structure,
+			func(rc *jsonschema.ReflectContext) {
+				rc.ProcessWithoutTags = false
+			},
+			jsonschema.InlineRefs,
+			jsonschema.PropertyNameTag("header"),
+			jsonschema.InterceptProp(func(params jsonschema.InterceptPropParams) error {
+				if !params.Processed || len(params.Path) > 1 { // only top-level fields
+					return nil
+				}
+				h := responseHeader{
+					name:       params.Name,
+					required:   params.Field.Tag.Get("required") == "true",
+					deprecated: params.Field.Tag.Get("deprecated") == "true",
+					schema:     *params.PropertySchema,
+				}
+				if params.PropertySchema.Description != nil {
+					h.description = *params.PropertySchema.Description
+					h.schema.Description = nil // documented on the header itself
+				}
+				headers = append(headers, h)
+				return nil
+			}),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, header := range cu.Headers {
+		schema, err := r.Reflect(header.Schema, jsonschema.InlineRefs)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, responseHeader{
+			name:        header.Name,
+			description: header.Description,
+			schema:      schema,
+		})
+	}
+	for i := range headers {
+		schemaMap, err := headers[i].schema.ToSchemaOrBool().ToSimpleMap()
+		if err != nil {
+			return nil, err
+		}
+		headers[i].schemaMap = schemaMap
+	}
+	return headers, nil
+}
+
+func (h responseHeader) oas3() openapi3.HeaderOrRef {
+	schema := openapi3.SchemaOrRef{}
+	schema.FromJSONSchema(h.schema.ToSchemaOrBool())
+
+	header := &openapi3.Header{Schema: &schema}
+	if h.description != "" {
+		header.WithDescription(h.description)
+	}
+	if h.required {
+		header.WithRequired(true)
+	}
+	if h.deprecated {
+		header.WithDeprecated(true)
+	}
+	return openapi3.HeaderOrRef{Header: header}
+}
+
+func (h responseHeader) oas31() openapi31.HeaderOrReference {
+	header := &openapi31.Header{Schema: h.schemaMap}
+	if h.description != "" {
+		header.WithDescription(h.description)
+	}
+	if h.required {
+		header.WithRequired(true)
+	}
+	if h.deprecated {
+		header.WithDeprecated(true)
+	}
+	return openapi31.HeaderOrReference{Header: header}
+}
+
+// customizeResponseHeaders returns a customizer adding the headers to a response.
+func customizeResponseHeaders(headers []responseHeader) func(cor openapi.ContentOrReference) {
+	return func(cor openapi.ContentOrReference) {
+		switch v := cor.(type) {
+		case *openapi3.ResponseOrRef:
+			for _, h := range headers {
+				v.Response.WithHeadersItem(h.name, h.oas3())
+			}
+		case *openapi31.ResponseOrReference:
+			for _, h := range headers {
+				v.Response.WithHeadersItem(h.name, h.oas31())
+			}
+		}
+	}
+}
+
+// customizeResponseHeaderRefs returns a customizer adding references to header components to a response.
+func customizeResponseHeaderRefs(refs map[string]string) func(cor openapi.ContentOrReference) {
+	return func(cor openapi.ContentOrReference) {
+		switch v := cor.(type) {
+		case *openapi3.ResponseOrRef:
+			for name, ref := range refs {
+				v.Response.WithHeadersItem(name, openapi3.HeaderOrRef{
+					HeaderReference: &openapi3.HeaderReference{Ref: ref},
+				})
+			}
+		case *openapi31.ResponseOrReference:
+			for name, ref := range refs {
+				v.Response.WithHeadersItem(name, openapi31.HeaderOrReference{
+					Reference: &openapi31.Reference{Ref: ref},
+				})
+			}
+		}
+	}
+}
@@ -107,10 +107,16 @@ func (l *Logger) LogSecurityScheme(name string, scheme *openapi.SecurityScheme)
 	switch {
 	case scheme.APIKey != nil:
 		typeInfo = "APIKey"
+	case scheme.HTTP != nil:
+		typeInfo = "HTTP"
 	case scheme.HTTPBearer != nil:
 		typeInfo = "HTTPBearer"
 	case scheme.OAuth2 != nil:
 		typeInfo = "OAuth2"
+	case scheme.OpenIDConnect != nil:
+		typeInfo = "OpenIDConnect"
+	case scheme.MutualTLS != nil:
+		typeInfo = "MutualTLS"
 	default:
 		typeInfo = "Unknown"
 	}
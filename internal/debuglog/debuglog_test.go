@@ -176,4 +176,13 @@ func TestLogger_LogSecurityScheme(t *testing.T) {
 	expected = "[test] add security scheme: name: oauth2, type: OAuth2, description: OAuth 2.0 authentication"
 	assert.Len(t, mockLog.messages, 3)
 	assert.Equal(t, expected, mockLog.messages[2])
+
+	logger.LogSecurityScheme("basicAuth", &openapi.SecurityScheme{HTTP: &openapi.SecuritySchemeHTTP{}})
+	logger.LogSecurityScheme("oidc", &openapi.SecurityScheme{OpenIDConnect: &openapi.SecuritySchemeOpenIDConnect{}})
+	logger.LogSecurityScheme("mtls", &openapi.SecurityScheme{MutualTLS: &openapi.SecuritySchemeMutualTLS{}})
+	assert.Equal(t, []string{
+		"[test] add security scheme: name: basicAuth, type: HTTP",
+		"[test] add security scheme: name: oidc, type: OpenIDConnect",
+		"[test] add security scheme: name: mtls, type: MutualTLS",
+	}, mockLog.messages[3:])
 }
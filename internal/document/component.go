@@ -0,0 +1,47 @@
+package document
+
+import "strings"
+
+// ComponentPointer returns the pointer to the component a local reference points into,
+// such as "#/components/schemas/Pet" for "#/components/schemas/Pet/properties/name".
+// Other references are returned unchanged.
+func ComponentPointer(ref string) string {
+	parts := strings.SplitN(ref, "/", 5)
+	if len(parts) < 4 || parts[0] != "#" || parts[1] != "components" {
+		return ref
+	}
+	return strings.Join(parts[:4], "/")
+}
+
+// ReachableComponents returns the pointers of the components referenced from the given
+// nodes, directly or through the components they reference, such as
+// "#/components/schemas/Pet". A component that only references itself is not reachable
+// unless a node references it.
+func (d *Document) ReachableComponents(nodes ...any) map[string]bool {
+	reached := map[string]bool{}
+	var walk func(node any)
+	walk = func(node any) {
+		switch v := node.(type) {
+		case map[string]any:
+			if ref, ok := v["$ref"].(string); ok {
+				if pointer := ComponentPointer(ref); !reached[pointer] {
+					reached[pointer] = true
+					if component, found := d.Lookup(pointer); found {
+						walk(component)
+					}
+				}
+			}
+			for _, child := range v {
+				walk(child)
+			}
+		case []any:
+			for _, child := range v {
+				walk(child)
+			}
+		}
+	}
+	for _, node := range nodes {
+		walk(node)
+	}
+	return reached
+}
@@ -0,0 +1,231 @@
+// Package document provides a version-agnostic view over a marshaled OpenAPI document.
+//
+// It is shared by the features that inspect the generated output rather than the
+// reflector state, such as request validation, response conformance and linting.
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidDocument is returned when the data is not an OpenAPI 3.x document.
+var ErrInvalidDocument = errors.New("invalid OpenAPI document")
+
+// Document wraps a decoded OpenAPI 3.0 or 3.1 document.
+type Document struct {
+	raw     map[string]any
+	version string
+
+	opsOnce sync.Once
+	ops     []Operation
+
+	mu       sync.Mutex
+	compiler *jsonschema.Compiler
+	schemas  map[string]*jsonschema.Schema
+}
+
+// Parse decodes a JSON or YAML OpenAPI document.
+func Parse(data []byte) (*Document, error) {
+	raw, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	version, _ := raw["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("%w: unsupported openapi version %q", ErrInvalidDocument, version)
+	}
+	return &Document{
+		raw:     raw,
+		version: version,
+		schemas: map[string]*jsonschema.Schema{},
+	}, nil
+}
+
+// Decode decodes a JSON or YAML object into JSON types, with numbers as json.Number.
+//
+// Unlike Parse, it does not require the data to be a complete OpenAPI document.
+func Decode(data []byte) (map[string]any, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.UseNumber()
+		var raw map[string]any
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+		}
+		return raw, nil
+	}
+
+	var node any
+	if err := yaml.Unmarshal(trimmed, &node); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDocument, err)
+	}
+	raw, ok := normalize(node).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: document root must be an object", ErrInvalidDocument)
+	}
+	return raw, nil
+}
+
+// normalize converts YAML decoded values into their JSON equivalents.
+func normalize(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = normalize(item)
+		}
+		return val
+	case map[any]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			m[fmt.Sprint(k)] = normalize(item)
+		}
+		return m
+	case []any:
+		for i, item := range val {
+			val[i] = normalize(item)
+		}
+		return val
+	case int:
+		return json.Number(strconv.Itoa(val))
+	case int64:
+		return json.Number(strconv.FormatInt(val, 10))
+	case uint64:
+		return json.Number(strconv.FormatUint(val, 10))
+	case float64:
+		return json.Number(strconv.FormatFloat(val, 'f', -1, 64))
+	default:
+		return val
+	}
+}
+
+// Version returns the value of the "openapi" field.
+func (d *Document) Version() string {
+	return d.version
+}
+
+// IsV31 reports whether the document uses OpenAPI 3.1.
+func (d *Document) IsV31() bool {
+	return strings.HasPrefix(d.version, "3.1")
+}
+
+// Raw returns the decoded document. Callers must not modify it.
+func (d *Document) Raw() map[string]any {
+	return d.raw
+}
+
+// Lookup returns the value at the given JSON pointer, e.g. "#/components/schemas/Pet".
+func (d *Document) Lookup(pointer string) (any, bool) {
+	pointer = strings.TrimPrefix(pointer, "#")
+	if pointer == "" {
+		return d.raw, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+	var cur any = d.raw
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = UnescapePointer(token)
+		switch node := cur.(type) {
+		case map[string]any:
+			next, ok := node[token]
+			if !ok {
+				return nil, false
+			}
+			cur = next
+		case []any:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(node) {
+				return nil, false
+			}
+			cur = node[idx]
+		default:
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// Resolve follows local "$ref" references starting at node.
+//
+// It returns the resolved object together with its JSON pointer.
+// The pointer argument is the location of node itself.
+func (d *Document) Resolve(node map[string]any, pointer string) (map[string]any, string) {
+	seen := map[string]bool{}
+	for node != nil {
+		ref, ok := node["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") || seen[ref] {
+			return node, pointer
+		}
+		seen[ref] = true
+		target, found := d.Lookup(ref)
+		if !found {
+			return node, pointer
+		}
+		next, isMap := target.(map[string]any)
+		if !isMap {
+			return node, pointer
+		}
+		node, pointer = next, ref
+	}
+	return node, pointer
+}
+
+// Pointer joins the given tokens into an escaped JSON pointer rooted at "#".
+func Pointer(tokens ...string) string {
+	var sb strings.Builder
+	sb.WriteString("#")
+	for _, token := range tokens {
+		sb.WriteString("/")
+		sb.WriteString(EscapePointer(token))
+	}
+	return sb.String()
+}
+
+// Append appends tokens to an existing JSON pointer.
+func Append(pointer string, tokens ...string) string {
+	return pointer + strings.TrimPrefix(Pointer(tokens...), "#")
+}
+
+// EscapePointer escapes a single JSON pointer token.
+func EscapePointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// UnescapePointer unescapes a single JSON pointer token.
+func UnescapePointer(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
+
+// Map returns the object stored under key, or nil.
+func Map(node map[string]any, key string) map[string]any {
+	v, _ := node[key].(map[string]any)
+	return v
+}
+
+// String returns the string stored under key, or an empty string.
+func String(node map[string]any, key string) string {
+	v, _ := node[key].(string)
+	return v
+}
+
+// Bool returns the boolean stored under key, or false.
+func Bool(node map[string]any, key string) bool {
+	v, _ := node[key].(bool)
+	return v
+}
+
+// Slice returns the array stored under key, or nil.
+func Slice(node map[string]any, key string) []any {
+	v, _ := node[key].([]any)
+	return v
+}
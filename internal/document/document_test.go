@@ -0,0 +1,265 @@
+package document_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec/internal/document"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petstoreYAML = `
+openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    parameters:
+    - in: path
+      name: petId
+      required: true
+      schema:
+        type: integer
+    get:
+      operationId: getPet
+      parameters:
+      - in: query
+        name: fields
+        schema:
+          type: array
+          items:
+            type: string
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /pets/mine:
+    get:
+      responses:
+        "200":
+          description: OK
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          nullable: true
+`
+
+func TestParse(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		doc, err := document.Parse([]byte(petstoreYAML))
+		require.NoError(t, err)
+		assert.Equal(t, "3.0.3", doc.Version())
+		assert.False(t, doc.IsV31())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		doc, err := document.Parse([]byte(`{"openapi":"3.1.0","info":{"title":"x","version":"1"}}`))
+		require.NoError(t, err)
+		assert.True(t, doc.IsV31())
+	})
+	t.Run("unsupported version", func(t *testing.T) {
+		_, err := document.Parse([]byte(`{"swagger":"2.0"}`))
+		require.ErrorIs(t, err, document.ErrInvalidDocument)
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := document.Parse([]byte(`{`))
+		require.Error(t, err)
+	})
+}
+
+func TestDocument_Lookup(t *testing.T) {
+	doc, err := document.Parse([]byte(petstoreYAML))
+	require.NoError(t, err)
+
+	value, ok := doc.Lookup(document.Pointer("paths", "/pets/{petId}", "get", "operationId"))
+	require.True(t, ok)
+	assert.Equal(t, "getPet", value)
+
+	_, ok = doc.Lookup("#/paths/missing")
+	assert.False(t, ok)
+
+	schema, pointer := doc.Resolve(map[string]any{"$ref": "#/components/schemas/Pet"}, "")
+	assert.Equal(t, "#/components/schemas/Pet", pointer)
+	assert.Equal(t, "object", document.String(schema, "type"))
+}
+
+func TestDocument_ReachableComponents(t *testing.T) {
+	doc, err := document.Parse([]byte(`
+openapi: 3.1.0
+info: {title: API, version: "1"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200": {$ref: "#/components/responses/Pets"}
+components:
+  responses:
+    Pets:
+      description: OK
+      content:
+        application/json:
+          schema: {type: array, items: {$ref: "#/components/schemas/Pet/properties/owner"}}
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner: {$ref: "#/components/schemas/Owner"}
+    Owner: {type: object}
+    Node:
+      type: object
+      properties:
+        next: {$ref: "#/components/schemas/Node"}
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{
+		"#/components/responses/Pets": true,
+		"#/components/schemas/Pet":    true,
+		"#/components/schemas/Owner":  true,
+	}, doc.ReachableComponents(doc.Raw()["paths"]))
+	assert.Equal(t, "#/components/schemas/Pet", document.ComponentPointer("#/components/schemas/Pet/properties/id"))
+	assert.Equal(t, "https://example.com/pet.json", document.ComponentPointer("https://example.com/pet.json"))
+}
+
+func TestDocument_Match(t *testing.T) {
+	doc, err := document.Parse([]byte(petstoreYAML))
+	require.NoError(t, err)
+
+	op, vars, ok := doc.Match("GET", "/pets/42")
+	require.True(t, ok)
+	assert.Equal(t, "/pets/{petId}", op.Path)
+	assert.Equal(t, map[string]string{"petId": "42"}, vars)
+	require.Len(t, op.Parameters, 2)
+	assert.Equal(t, "petId", op.Parameters[0].Name)
+	assert.Equal(t, "simple", op.Parameters[0].Style)
+	assert.Equal(t, "form", op.Parameters[1].Style)
+	assert.True(t, op.Parameters[1].Explode)
+
+	op, _, ok = doc.Match("GET", "/pets/mine")
+	require.True(t, ok)
+	assert.Equal(t, "/pets/mine", op.Path)
+
+	_, _, ok = doc.Match("POST", "/pets/42")
+	assert.False(t, ok)
+}
+
+func TestDocument_WebhooksAndCallbacks(t *testing.T) {
+	doc, err := document.Parse([]byte(`
+openapi: 3.1.0
+info: {title: Jobs, version: 1.0.0}
+paths:
+  /jobs:
+    post:
+      operationId: createJob
+      callbacks:
+        onComplete:
+          "{$request.body#/callbackUrl}":
+            post:
+              operationId: jobCompleted
+            put:
+              operationId: jobReplaced
+webhooks:
+  jobDeleted:
+    post:
+      operationId: jobDeleted
+`))
+	require.NoError(t, err)
+
+	ops := doc.Operations()
+	require.Len(t, ops, 1)
+
+	callbacks := doc.Callbacks(ops[0])
+	require.Len(t, callbacks, 2)
+	assert.Equal(t, "PUT", callbacks[0].Method)
+	assert.Equal(t, "{$request.body#/callbackUrl}", callbacks[0].Path)
+	assert.Equal(t, "#/paths/~1jobs/post/callbacks/onComplete/{$request.body#~1callbackUrl}/put", callbacks[0].Pointer)
+	assert.Equal(t, "jobCompleted", document.String(callbacks[1].Node, "operationId"))
+
+	webhooks := doc.Webhooks()
+	require.Len(t, webhooks, 1)
+	assert.Equal(t, "jobDeleted", webhooks[0].Path)
+	assert.Equal(t, "#/webhooks/jobDeleted/post", webhooks[0].Pointer)
+}
+
+func TestDocument_ValidateSchema(t *testing.T) {
+	doc, err := document.Parse([]byte(petstoreYAML))
+	require.NoError(t, err)
+
+	pointer := "#/components/schemas/Pet"
+	violations, err := doc.ValidateSchema(pointer, map[string]any{"name": "Rex", "tag": nil})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = doc.ValidateSchema(pointer, map[string]any{"tag": 1})
+	require.NoError(t, err)
+	assert.Equal(t, []document.Violation{
+		{Pointer: "", Message: "missing properties: 'name'"},
+		{Pointer: "/tag", Message: "expected string or null, but got number"},
+	}, violations)
+}
+
+func TestValidateOpenAPI(t *testing.T) {
+	doc, err := document.Decode([]byte(petstoreYAML))
+	require.NoError(t, err)
+	violations, err := document.ValidateOpenAPI(doc)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	doc, err = document.Decode([]byte(`
+openapi: 3.1.0
+info: {title: API}
+servers: [{url: "https://api.example.com/{version}", variables: {version: {enum: [v1]}}}]
+paths: {}
+`))
+	require.NoError(t, err)
+	violations, err = document.ValidateOpenAPI(doc)
+	require.NoError(t, err)
+	assert.Equal(t, []document.Violation{
+		{Pointer: "/info", Message: "missing properties: 'version'"},
+		{Pointer: "/servers/0/variables/version", Message: "missing properties: 'default'"},
+	}, violations)
+
+	_, err = document.ValidateOpenAPI(map[string]any{"swagger": "2.0"})
+	require.ErrorIs(t, err, document.ErrInvalidDocument)
+}
+
+func TestMatchMediaType(t *testing.T) {
+	content := map[string]any{
+		"application/json": map[string]any{},
+		"image/*":          map[string]any{},
+		"*/*":              map[string]any{},
+	}
+	tests := []struct {
+		mediaType string
+		want      string
+	}{
+		{"application/json; charset=utf-8", "application/json"},
+		{"Application/JSON", "application/json"},
+		{"image/png", "image/*"},
+		{"text/plain", "*/*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mediaType, func(t *testing.T) {
+			got, ok := document.MatchMediaType(content, tt.mediaType)
+			require.True(t, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	assert.True(t, document.IsJSONMediaType("application/problem+json"))
+	assert.False(t, document.IsJSONMediaType("text/plain"))
+}
+
+func TestPathParams(t *testing.T) {
+	assert.Equal(t, []string{"tenant", "id"}, document.PathParams("/tenants/{tenant}/pets/{id}"))
+	assert.Empty(t, document.PathParams("/pets"))
+}
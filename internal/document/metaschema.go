@@ -0,0 +1,85 @@
+package document
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// The official OpenAPI schemas, published at https://spec.openapis.org/oas/3.0/schema/2019-04-02
+// and https://spec.openapis.org/oas/3.1/schema/2022-10-07, are embedded so documents can be
+// validated offline.
+//
+//go:embed metaschema/*.json
+var metaSchemaFS embed.FS
+
+//nolint:gochecknoglobals // compiled once and read-only afterwards
+var metaSchemas struct {
+	once    sync.Once
+	schemas map[string]*jsonschema.Schema // minor version -> schema
+	err     error
+}
+
+// ValidateOpenAPI validates a decoded document against the official schema of its
+// OpenAPI version. Like the official schemas, it does not validate the schema objects
+// of OpenAPI 3.1 documents, which may use any JSON Schema dialect.
+//
+// It returns the violations found, or an error if the version is not supported.
+func ValidateOpenAPI(doc map[string]any) ([]Violation, error) {
+	metaSchemas.once.Do(compileMetaSchemas)
+	if metaSchemas.err != nil {
+		return nil, metaSchemas.err
+	}
+	version, _ := doc["openapi"].(string)
+	schema, ok := metaSchemas.schemas[minorVersion(version)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported openapi version %q", ErrInvalidDocument, version)
+	}
+	err := schema.Validate(doc)
+	if err == nil {
+		return nil, nil
+	}
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return nil, err
+	}
+	return Violations(ve), nil
+}
+
+func compileMetaSchemas() {
+	metaSchemas.schemas = map[string]*jsonschema.Schema{}
+	for _, version := range []string{"3.0", "3.1"} {
+		name := "metaschema/openapi-" + version + ".json"
+		file, err := metaSchemaFS.Open(name)
+		if err != nil {
+			metaSchemas.err = err
+			return
+		}
+		compiler := jsonschema.NewCompiler()
+		compiler.AssertFormat = true
+		err = compiler.AddResource(name, file)
+		file.Close()
+		if err != nil {
+			metaSchemas.err = fmt.Errorf("failed to load OpenAPI %s schema: %w", version, err)
+			return
+		}
+		schema, err := compiler.Compile(name)
+		if err != nil {
+			metaSchemas.err = fmt.Errorf("failed to compile OpenAPI %s schema: %w", version, err)
+			return
+		}
+		metaSchemas.schemas[version] = schema
+	}
+}
+
+func minorVersion(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
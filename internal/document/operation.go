@@ -0,0 +1,272 @@
+package document
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Methods lists the path item fields that hold operations, in document order.
+//
+//nolint:gochecknoglobals // read-only lookup table
+var Methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Operation describes a single operation found under "paths", "webhooks" or callbacks.
+type Operation struct {
+	Method     string         // Upper-case HTTP method.
+	Path       string         // Path template, e.g. "/pets/{petId}".
+	Pointer    string         // JSON pointer to the operation object.
+	Node       map[string]any // The operation object.
+	Parameters []Parameter    // Path item and operation parameters with references resolved.
+}
+
+// Parameter describes a resolved parameter object.
+type Parameter struct {
+	Name     string
+	In       string
+	Required bool
+	Style    string
+	Explode  bool
+	Pointer  string         // JSON pointer to the parameter object.
+	Node     map[string]any // The parameter object.
+}
+
+// Operations returns all operations ordered by path and method.
+func (d *Document) Operations() []Operation {
+	d.opsOnce.Do(func() {
+		d.ops = d.collectOperations()
+	})
+	return d.ops
+}
+
+func (d *Document) collectOperations() []Operation {
+	return d.pathItemOperations(Map(d.raw, "paths"), Pointer("paths"))
+}
+
+// Webhooks returns the operations under "webhooks", ordered by name and method.
+// The Path of each operation is the webhook name.
+func (d *Document) Webhooks() []Operation {
+	return d.pathItemOperations(Map(d.raw, "webhooks"), Pointer("webhooks"))
+}
+
+// Callbacks returns the operations of the callbacks of op, ordered by callback name,
+// expression and method. The Path of each operation is the callback expression.
+func (d *Document) Callbacks(op Operation) []Operation {
+	callbacks := Map(op.Node, "callbacks")
+
+	var ops []Operation
+	for _, name := range SortedKeys(callbacks) {
+		callback, callbackPtr := d.Resolve(Map(callbacks, name), Append(op.Pointer, "callbacks", name))
+		ops = append(ops, d.pathItemOperations(callback, callbackPtr)...)
+	}
+	return ops
+}
+
+// pathItemOperations returns the operations of a map of path items.
+func (d *Document) pathItemOperations(items map[string]any, pointer string) []Operation {
+	var ops []Operation
+	for _, path := range SortedKeys(items) {
+		item, itemPtr := d.Resolve(Map(items, path), Append(pointer, path))
+		if item == nil {
+			continue
+		}
+		for _, method := range Methods {
+			node := Map(item, method)
+			if node == nil {
+				continue
+			}
+			ptr := Append(itemPtr, method)
+			ops = append(ops, Operation{
+				Method:     strings.ToUpper(method),
+				Path:       path,
+				Pointer:    ptr,
+				Node:       node,
+				Parameters: d.parameters(item, itemPtr, node, ptr),
+			})
+		}
+	}
+	return ops
+}
+
+// Operation returns the operation registered for the given method and path template.
+func (d *Document) Operation(method, path string) (Operation, bool) {
+	for _, op := range d.Operations() {
+		if op.Path == path && strings.EqualFold(op.Method, method) {
+			return op, true
+		}
+	}
+	return Operation{}, false
+}
+
+func (d *Document) parameters(item map[string]any, itemPtr string, op map[string]any, opPtr string) []Parameter {
+	var params []Parameter
+	index := map[string]int{}
+	add := func(list []any, base string) {
+		for i, raw := range list {
+			node, _ := raw.(map[string]any)
+			node, ptr := d.Resolve(node, Append(base, "parameters", strconv.Itoa(i)))
+			if node == nil {
+				continue
+			}
+			param := newParameter(node, ptr)
+			key := param.In + ":" + param.Name
+			if idx, ok := index[key]; ok {
+				params[idx] = param // Operation parameters override path item ones.
+				continue
+			}
+			index[key] = len(params)
+			params = append(params, param)
+		}
+	}
+	add(Slice(item, "parameters"), itemPtr)
+	add(Slice(op, "parameters"), opPtr)
+	return params
+}
+
+func newParameter(node map[string]any, ptr string) Parameter {
+	p := Parameter{
+		Name:     String(node, "name"),
+		In:       String(node, "in"),
+		Required: Bool(node, "required"),
+		Style:    String(node, "style"),
+		Pointer:  ptr,
+		Node:     node,
+	}
+	if p.Style == "" {
+		switch p.In {
+		case "query", "cookie":
+			p.Style = "form"
+		default:
+			p.Style = "simple"
+		}
+	}
+	p.Explode = p.Style == "form"
+	if explode, ok := node["explode"].(bool); ok {
+		p.Explode = explode
+	}
+	return p
+}
+
+// Schema returns the schema object of the parameter and its pointer.
+//
+// Parameters described with "content" use the schema of their first media type.
+func (p Parameter) Schema() (map[string]any, string) {
+	if schema := Map(p.Node, "schema"); schema != nil {
+		return schema, Append(p.Pointer, "schema")
+	}
+	content := Map(p.Node, "content")
+	for _, mt := range SortedKeys(content) {
+		if schema := Map(Map(content, mt), "schema"); schema != nil {
+			return schema, Append(p.Pointer, "content", mt, "schema")
+		}
+	}
+	return nil, ""
+}
+
+// Match finds the operation whose path template matches the concrete request path.
+//
+// Literal segments take precedence over templated ones, so "/pets/mine" wins over
+// "/pets/{petId}". It returns the operation and the extracted path parameters.
+func (d *Document) Match(method, path string) (Operation, map[string]string, bool) {
+	segments := splitPath(path)
+
+	var (
+		best      Operation
+		bestVars  map[string]string
+		bestScore = -1
+	)
+	for _, op := range d.Operations() {
+		if !strings.EqualFold(op.Method, method) {
+			continue
+		}
+		vars, score, ok := matchTemplate(splitPath(op.Path), segments)
+		if ok && score > bestScore {
+			best, bestVars, bestScore = op, vars, score
+		}
+	}
+	return best, bestVars, bestScore >= 0
+}
+
+func matchTemplate(template, segments []string) (map[string]string, int, bool) {
+	if len(template) != len(segments) {
+		return nil, 0, false
+	}
+	vars := map[string]string{}
+	score := 0
+	for i, tpl := range template {
+		seg := segments[i]
+		open := strings.Index(tpl, "{")
+		if open < 0 {
+			if tpl != seg {
+				return nil, 0, false
+			}
+			score++
+			continue
+		}
+		closing := strings.LastIndex(tpl, "}")
+		if closing < open {
+			return nil, 0, false
+		}
+		prefix, suffix := tpl[:open], tpl[closing+1:]
+		if !strings.HasPrefix(seg, prefix) || !strings.HasSuffix(seg, suffix) ||
+			len(seg) < len(prefix)+len(suffix) {
+			return nil, 0, false
+		}
+		vars[tpl[open+1:closing]] = seg[len(prefix) : len(seg)-len(suffix)]
+	}
+	return vars, score, true
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// PathParams returns the parameter names used in a path template, in order.
+func PathParams(path string) []string {
+	var names []string
+	for {
+		open := strings.Index(path, "{")
+		if open < 0 {
+			return names
+		}
+		closing := strings.Index(path[open:], "}")
+		if closing < 0 {
+			return names
+		}
+		names = append(names, path[open+1:open+closing])
+		path = path[open+closing+1:]
+	}
+}
+
+// SortedKeys returns the keys of m in lexical order.
+func SortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Response returns the response object declared for status on op.
+//
+// An exact status code wins over a range such as "2XX", which wins over "default".
+// The returned pointer locates the resolved response object.
+func (d *Document) Response(op Operation, status int) (map[string]any, string, bool) {
+	responses := Map(op.Node, "responses")
+	code := strconv.Itoa(status)
+	keys := []string{code, code[:1] + "XX", code[:1] + "xx", "default"}
+	for _, key := range keys {
+		node := Map(responses, key)
+		if node == nil {
+			continue
+		}
+		resolved, pointer := d.Resolve(node, Append(op.Pointer, "responses", key))
+		return resolved, pointer, true
+	}
+	return nil, "", false
+}
@@ -0,0 +1,213 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// resourceURL is the in-memory location of the document inside the schema compiler.
+const resourceURL = "file:///openapi.json"
+
+// Violation describes a single schema validation failure.
+type Violation struct {
+	Pointer string // JSON pointer into the validated value, e.g. "/items/0/name".
+	Message string // Human-readable description of the failure.
+}
+
+// ValidateSchema validates value against the schema located at pointer.
+//
+// Values must use JSON types, with numbers as json.Number or Go numeric types.
+// It returns the violations found, or an error if the schema cannot be compiled.
+func (d *Document) ValidateSchema(pointer string, value any) ([]Violation, error) {
+	schema, err := d.compile(pointer)
+	if err != nil {
+		return nil, err
+	}
+	err = schema.Validate(value)
+	if err == nil {
+		return nil, nil
+	}
+	var ve *jsonschema.ValidationError
+	if !errors.As(err, &ve) {
+		return nil, err
+	}
+	return Violations(ve), nil
+}
+
+// Violations flattens a validation error into its leaf causes.
+func Violations(ve *jsonschema.ValidationError) []Violation {
+	var out []Violation
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			out = append(out, Violation{Pointer: e.InstanceLocation, Message: e.Message})
+			return
+		}
+		for _, cause := range e.Causes {
+			walk(cause)
+		}
+	}
+	walk(ve)
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Pointer < out[j].Pointer
+	})
+	return out
+}
+
+func (d *Document) compile(pointer string) (*jsonschema.Schema, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if schema, ok := d.schemas[pointer]; ok {
+		return schema, nil
+	}
+	if d.compiler == nil {
+		compiler, err := d.newCompiler()
+		if err != nil {
+			return nil, err
+		}
+		d.compiler = compiler
+	}
+	schema, err := d.compiler.Compile(resourceURL + pointer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %s: %w", pointer, err)
+	}
+	d.schemas[pointer] = schema
+	return schema, nil
+}
+
+func (d *Document) newCompiler() (*jsonschema.Compiler, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("external reference %q is not supported", s)
+	}
+	compiler.AssertFormat = true
+
+	var raw any = d.raw
+	if d.IsV31() {
+		compiler.Draft = jsonschema.Draft2020
+	} else {
+		// OpenAPI 3.0 schemas are a superset of JSON Schema draft 4,
+		// with "nullable" in place of the "null" type.
+		compiler.Draft = jsonschema.Draft4
+		raw = expandNullable(deepCopy(d.raw))
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err = compiler.AddResource(resourceURL, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+	return compiler, nil
+}
+
+// expandNullable rewrites OpenAPI 3.0 "nullable: true" into JSON Schema "null" types.
+func expandNullable(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			val[k] = expandNullable(item)
+		}
+		if nullable, ok := val["nullable"].(bool); ok && nullable {
+			if typ, isString := val["type"].(string); isString {
+				val["type"] = []any{typ, "null"}
+			}
+			if enum, isSlice := val["enum"].([]any); isSlice {
+				val["enum"] = append(enum, nil)
+			}
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = expandNullable(item)
+		}
+		return val
+	default:
+		return val
+	}
+}
+
+func deepCopy(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		m := make(map[string]any, len(val))
+		for k, item := range val {
+			m[k] = deepCopy(item)
+		}
+		return m
+	case []any:
+		s := make([]any, len(val))
+		for i, item := range val {
+			s[i] = deepCopy(item)
+		}
+		return s
+	default:
+		return val
+	}
+}
+
+// SchemaType returns the primary JSON type of a schema, resolving references.
+//
+// For multi-typed schemas, such as ["integer", "null"], the first non-null type is returned.
+func (d *Document) SchemaType(schema map[string]any) string {
+	schema, _ = d.Resolve(schema, "")
+	switch typ := schema["type"].(type) {
+	case string:
+		return typ
+	case []any:
+		for _, t := range typ {
+			if s, ok := t.(string); ok && s != "null" {
+				return s
+			}
+		}
+	}
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		for _, sub := range Slice(schema, key) {
+			if m, ok := sub.(map[string]any); ok {
+				if typ := d.SchemaType(m); typ != "" {
+					return typ
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// IsJSONMediaType reports whether the media type carries a JSON payload.
+func IsJSONMediaType(mediaType string) bool {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// MatchMediaType picks the entry of content that best matches the given media type.
+//
+// Exact matches win over "type/*" ranges, which win over "*/*".
+func MatchMediaType(content map[string]any, mediaType string) (string, bool) {
+	mediaType = strings.ToLower(strings.TrimSpace(strings.Split(mediaType, ";")[0]))
+	if _, ok := content[mediaType]; ok {
+		return mediaType, true
+	}
+	for _, key := range SortedKeys(content) {
+		if strings.EqualFold(strings.TrimSpace(strings.Split(key, ";")[0]), mediaType) {
+			return key, true
+		}
+	}
+	if slash := strings.Index(mediaType, "/"); slash > 0 {
+		if _, ok := content[mediaType[:slash]+"/*"]; ok {
+			return mediaType[:slash] + "/*", true
+		}
+	}
+	if _, ok := content["*/*"]; ok {
+		return "*/*", true
+	}
+	return "", false
+}
@@ -26,6 +26,11 @@ func (se *SpecError) Errors() []error {
 	return se.errors
 }
 
+// Unwrap returns the collected errors, so errors.Is and errors.As can inspect them.
+func (se *SpecError) Unwrap() []error {
+	return se.Errors()
+}
+
 // Error implements the error interface for SpecError.
 func (se *SpecError) Error() string {
 	se.mu.Lock()
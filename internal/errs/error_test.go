@@ -72,6 +72,15 @@ func TestSpecError_HasErrors(t *testing.T) {
 	assert.True(t, se.HasErrors())
 }
 
+func TestSpecError_Unwrap(t *testing.T) {
+	target := errors.New("target error")
+	se := &errs.SpecError{}
+	se.Add(errors.New("test error"))
+	se.Add(target)
+
+	assert.ErrorIs(t, se, target)
+}
+
 func TestSpecError_ConcurrentAccess(t *testing.T) {
 	se := &errs.SpecError{}
 	var wg sync.WaitGroup
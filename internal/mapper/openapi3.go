@@ -119,13 +119,18 @@ func OAS3SecurityScheme(scheme *openapi.SecurityScheme) *openapi3.SecurityScheme
 		return nil
 	}
 	oasSecurityScheme := &openapi3.SecurityScheme{
-		APIKeySecurityScheme: OAS3APIKey(scheme, scheme.APIKey),
-		HTTPSecurityScheme:   OAS3HTTPBearer(scheme.HTTPBearer, scheme.Description),
-		OAuth2SecurityScheme: OAS3OAuth2SecurityScheme(scheme.OAuth2, scheme.Description),
+		APIKeySecurityScheme:        OAS3APIKey(scheme, scheme.APIKey),
+		HTTPSecurityScheme:          OAS3HTTPBearer(scheme.HTTPBearer, scheme.Description),
+		OAuth2SecurityScheme:        OAS3OAuth2SecurityScheme(scheme.OAuth2, scheme.Description),
+		OpenIDConnectSecurityScheme: OAS3OpenIDConnect(scheme.OpenIDConnect, scheme.Description),
+	}
+	if oasSecurityScheme.HTTPSecurityScheme == nil {
+		oasSecurityScheme.HTTPSecurityScheme = OAS3HTTP(scheme.HTTP, scheme.Description)
 	}
 	if oasSecurityScheme.APIKeySecurityScheme == nil &&
 		oasSecurityScheme.HTTPSecurityScheme == nil &&
-		oasSecurityScheme.OAuth2SecurityScheme == nil {
+		oasSecurityScheme.OAuth2SecurityScheme == nil &&
+		oasSecurityScheme.OpenIDConnectSecurityScheme == nil {
 		return nil // No valid security scheme defined
 	}
 	return oasSecurityScheme
@@ -156,6 +161,32 @@ func OAS3HTTPBearer(
 	}
 }
 
+func OAS3HTTP(
+	securityScheme *openapi.SecuritySchemeHTTP,
+	description *string,
+) *openapi3.HTTPSecurityScheme {
+	if securityScheme == nil {
+		return nil
+	}
+	return &openapi3.HTTPSecurityScheme{
+		Description: description,
+		Scheme:      securityScheme.Scheme,
+	}
+}
+
+func OAS3OpenIDConnect(
+	securityScheme *openapi.SecuritySchemeOpenIDConnect,
+	description *string,
+) *openapi3.OpenIDConnectSecurityScheme {
+	if securityScheme == nil {
+		return nil
+	}
+	return &openapi3.OpenIDConnectSecurityScheme{
+		Description:      description,
+		OpenIDConnectURL: securityScheme.OpenIDConnectURL,
+	}
+}
+
 func OAS3OAuth2SecurityScheme(
 	oauth2 *openapi.SecuritySchemeOAuth2,
 	description *string,
@@ -225,3 +256,61 @@ func OAS3OauthFlowsAuthorizationCode(flows *openapi.OAuthFlowsAuthorizationCode)
 		MapOfAnything:    flows.MapOfAnything,
 	}
 }
+
+func OAS3Links(links map[string]openapi.Link) map[string]openapi3.LinkOrRef {
+	if len(links) == 0 {
+		return nil
+	}
+	result := make(map[string]openapi3.LinkOrRef, len(links))
+	for name, link := range links {
+		result[name] = openapi3.LinkOrRef{Link: OAS3Link(link)}
+	}
+	return result
+}
+
+func OAS3Link(link openapi.Link) *openapi3.Link {
+	result := &openapi3.Link{
+		Parameters: link.Parameters,
+	}
+	if link.OperationID != "" {
+		result.OperationID = &link.OperationID
+	}
+	if link.OperationRef != "" {
+		result.OperationRef = &link.OperationRef
+	}
+	if link.RequestBody != nil {
+		result.RequestBody = &link.RequestBody
+	}
+	if link.Description != "" {
+		result.Description = &link.Description
+	}
+	return result
+}
+
+func OAS3Examples(examples map[string]openapi.Example) map[string]openapi3.ExampleOrRef {
+	if len(examples) == 0 {
+		return nil
+	}
+	result := make(map[string]openapi3.ExampleOrRef, len(examples))
+	for name, example := range examples {
+		result[name] = openapi3.ExampleOrRef{Example: OAS3Example(example)}
+	}
+	return result
+}
+
+func OAS3Example(example openapi.Example) *openapi3.Example {
+	result := &openapi3.Example{}
+	if example.Summary != "" {
+		result.Summary = &example.Summary
+	}
+	if example.Description != "" {
+		result.Description = &example.Description
+	}
+	if example.Value != nil {
+		result.Value = &example.Value
+	}
+	if example.ExternalValue != "" {
+		result.ExternalValue = &example.ExternalValue
+	}
+	return result
+}
@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"fmt"
+
 	"github.com/oaswrap/spec/openapi"
 	"github.com/swaggest/openapi-go/openapi31"
 )
@@ -122,10 +124,14 @@ func OAS31SecurityScheme(scheme *openapi.SecurityScheme) *openapi31.SecuritySche
 		Description:   scheme.Description,
 		MapOfAnything: scheme.MapOfAnything,
 		APIKey:        OAS31APIKey(scheme.APIKey),
+		HTTP:          OAS31HTTP(scheme.HTTP),
 		HTTPBearer:    OAS31HTTPBearer(scheme.HTTPBearer),
 		Oauth2:        OAS31SecuritySchemeOauth2(scheme.OAuth2),
+		Oidc:          OAS31OpenIDConnect(scheme.OpenIDConnect),
+		MutualTLS:     OAS31MutualTLS(scheme.MutualTLS),
 	}
-	if openapiScheme.APIKey == nil && openapiScheme.HTTPBearer == nil && openapiScheme.Oauth2 == nil {
+	if openapiScheme.APIKey == nil && openapiScheme.HTTP == nil && openapiScheme.HTTPBearer == nil &&
+		openapiScheme.Oauth2 == nil && openapiScheme.Oidc == nil && openapiScheme.MutualTLS == nil {
 		return nil // No valid security scheme defined
 	}
 	return openapiScheme
@@ -151,6 +157,31 @@ func OAS31HTTPBearer(scheme *openapi.SecuritySchemeHTTPBearer) *openapi31.Securi
 	}
 }
 
+func OAS31HTTP(scheme *openapi.SecuritySchemeHTTP) *openapi31.SecuritySchemeHTTP {
+	if scheme == nil {
+		return nil
+	}
+	return &openapi31.SecuritySchemeHTTP{
+		Scheme: scheme.Scheme,
+	}
+}
+
+func OAS31OpenIDConnect(scheme *openapi.SecuritySchemeOpenIDConnect) *openapi31.SecuritySchemeOidc {
+	if scheme == nil {
+		return nil
+	}
+	return &openapi31.SecuritySchemeOidc{
+		OpenIDConnectURL: scheme.OpenIDConnectURL,
+	}
+}
+
+func OAS31MutualTLS(scheme *openapi.SecuritySchemeMutualTLS) *openapi31.MutualTLS {
+	if scheme == nil {
+		return nil
+	}
+	return &openapi31.MutualTLS{}
+}
+
 func OAS31SecuritySchemeOauth2(oauth2 *openapi.SecuritySchemeOAuth2) *openapi31.SecuritySchemeOauth2 {
 	if oauth2 == nil {
 		return nil
@@ -220,3 +251,66 @@ func OAS31OauthFlowsAuthorizationCode(
 		MapOfAnything:    flows.MapOfAnything,
 	}
 }
+
+func OAS31Links(links map[string]openapi.Link) map[string]openapi31.LinkOrReference {
+	if len(links) == 0 {
+		return nil
+	}
+	result := make(map[string]openapi31.LinkOrReference, len(links))
+	for name, link := range links {
+		result[name] = openapi31.LinkOrReference{Link: OAS31Link(link)}
+	}
+	return result
+}
+
+func OAS31Link(link openapi.Link) *openapi31.Link {
+	result := &openapi31.Link{}
+	if len(link.Parameters) > 0 {
+		// The 3.1 model only holds string values, so constants are formatted.
+		result.Parameters = make(map[string]string, len(link.Parameters))
+		for name, value := range link.Parameters {
+			result.Parameters[name] = fmt.Sprint(value)
+		}
+	}
+	if link.OperationID != "" {
+		result.OperationID = &link.OperationID
+	}
+	if link.OperationRef != "" {
+		result.OperationRef = &link.OperationRef
+	}
+	if link.RequestBody != nil {
+		result.RequestBody = &link.RequestBody
+	}
+	if link.Description != "" {
+		result.Description = &link.Description
+	}
+	return result
+}
+
+func OAS31Examples(examples map[string]openapi.Example) map[string]openapi31.ExampleOrReference {
+	if len(examples) == 0 {
+		return nil
+	}
+	result := make(map[string]openapi31.ExampleOrReference, len(examples))
+	for name, example := range examples {
+		result[name] = openapi31.ExampleOrReference{Example: OAS31Example(example)}
+	}
+	return result
+}
+
+func OAS31Example(example openapi.Example) *openapi31.Example {
+	result := &openapi31.Example{}
+	if example.Summary != "" {
+		result.Summary = &example.Summary
+	}
+	if example.Description != "" {
+		result.Description = &example.Description
+	}
+	if example.Value != nil {
+		result.Value = &example.Value
+	}
+	if example.ExternalValue != "" {
+		result.ExternalValue = &example.ExternalValue
+	}
+	return result
+}
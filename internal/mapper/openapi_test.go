@@ -608,6 +608,81 @@ func TestOASServer(t *testing.T) {
 	}
 }
 
+func TestOASLinks(t *testing.T) {
+	assert.Nil(t, mapper.OAS3Links(nil))
+	assert.Nil(t, mapper.OAS31Links(nil))
+
+	links := map[string]openapi.Link{
+		"GetPet": {
+			OperationID: "getPet",
+			Parameters:  map[string]any{"petId": "$response.body#/id", "verbose": true},
+		},
+		"UpdatePet": {
+			OperationRef: "#/paths/~1pets~1{petId}/patch",
+			RequestBody:  "$request.body",
+			Description:  "Update the pet",
+		},
+	}
+
+	var requestBody any = "$request.body"
+	assert.Equal(t, map[string]openapi3.LinkOrRef{
+		"GetPet": {Link: &openapi3.Link{
+			OperationID: util.PtrOf("getPet"),
+			Parameters:  map[string]any{"petId": "$response.body#/id", "verbose": true},
+		}},
+		"UpdatePet": {Link: &openapi3.Link{
+			OperationRef: util.PtrOf("#/paths/~1pets~1{petId}/patch"),
+			RequestBody:  &requestBody,
+			Description:  util.PtrOf("Update the pet"),
+		}},
+	}, mapper.OAS3Links(links))
+
+	assert.Equal(t, map[string]openapi31.LinkOrReference{
+		"GetPet": {Link: &openapi31.Link{
+			OperationID: util.PtrOf("getPet"),
+			Parameters:  map[string]string{"petId": "$response.body#/id", "verbose": "true"},
+		}},
+		"UpdatePet": {Link: &openapi31.Link{
+			OperationRef: util.PtrOf("#/paths/~1pets~1{petId}/patch"),
+			RequestBody:  &requestBody,
+			Description:  util.PtrOf("Update the pet"),
+		}},
+	}, mapper.OAS31Links(links))
+}
+
+func TestOASExamples(t *testing.T) {
+	assert.Nil(t, mapper.OAS3Examples(nil))
+	assert.Nil(t, mapper.OAS31Examples(nil))
+
+	examples := map[string]openapi.Example{
+		"dog": {
+			Summary:     "A dog",
+			Description: "A good boy",
+			Value:       map[string]any{"name": "Rex"},
+		},
+		"remote": {ExternalValue: "https://example.com/pet.json"},
+	}
+
+	var value any = map[string]any{"name": "Rex"}
+	assert.Equal(t, map[string]openapi3.ExampleOrRef{
+		"dog": {Example: &openapi3.Example{
+			Summary:     util.PtrOf("A dog"),
+			Description: util.PtrOf("A good boy"),
+			Value:       &value,
+		}},
+		"remote": {Example: &openapi3.Example{ExternalValue: util.PtrOf("https://example.com/pet.json")}},
+	}, mapper.OAS3Examples(examples))
+
+	assert.Equal(t, map[string]openapi31.ExampleOrReference{
+		"dog": {Example: &openapi31.Example{
+			Summary:     util.PtrOf("A dog"),
+			Description: util.PtrOf("A good boy"),
+			Value:       &value,
+		}},
+		"remote": {Example: &openapi31.Example{ExternalValue: util.PtrOf("https://example.com/pet.json")}},
+	}, mapper.OAS31Examples(examples))
+}
+
 func TestOASSecurityScheme(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -645,6 +720,51 @@ func TestOASSecurityScheme(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "HTTP Basic scheme",
+			scheme: &openapi.SecurityScheme{
+				Description: util.PtrOf("Basic auth"),
+				HTTP:        &openapi.SecuritySchemeHTTP{Scheme: "basic"},
+			},
+			expected3: &openapi3.SecurityScheme{
+				HTTPSecurityScheme: &openapi3.HTTPSecurityScheme{
+					Description: util.PtrOf("Basic auth"),
+					Scheme:      "basic",
+				},
+			},
+			expected31: &openapi31.SecurityScheme{
+				Description: util.PtrOf("Basic auth"),
+				HTTP:        &openapi31.SecuritySchemeHTTP{Scheme: "basic"},
+			},
+		},
+		{
+			name: "OpenID Connect scheme",
+			scheme: &openapi.SecurityScheme{
+				OpenIDConnect: &openapi.SecuritySchemeOpenIDConnect{
+					OpenIDConnectURL: "https://auth.example.com/.well-known/openid-configuration",
+				},
+			},
+			expected3: &openapi3.SecurityScheme{
+				OpenIDConnectSecurityScheme: &openapi3.OpenIDConnectSecurityScheme{
+					OpenIDConnectURL: "https://auth.example.com/.well-known/openid-configuration",
+				},
+			},
+			expected31: &openapi31.SecurityScheme{
+				Oidc: &openapi31.SecuritySchemeOidc{
+					OpenIDConnectURL: "https://auth.example.com/.well-known/openid-configuration",
+				},
+			},
+		},
+		{
+			name: "mutual TLS scheme",
+			scheme: &openapi.SecurityScheme{
+				MutualTLS: &openapi.SecuritySchemeMutualTLS{},
+			},
+			expected3: nil,
+			expected31: &openapi31.SecurityScheme{
+				MutualTLS: &openapi31.MutualTLS{},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,73 @@
+package spec
+
+import (
+	"reflect"
+	"strings"
+
+	specopenapi "github.com/oaswrap/spec/openapi"
+)
+
+const (
+	mimeJSON           = "application/json"
+	mimeFormUrlencoded = "application/x-www-form-urlencoded"
+	mimeMultipart      = "multipart/form-data"
+)
+
+// contentMediaTypes returns the media types a content unit is documented under.
+//
+// Without an explicit content type, a request body is reflected as JSON or as a form
+// depending on its field tags, and a response body as JSON.
+func contentMediaTypes(cu *specopenapi.ContentUnit) []string {
+	if cu.ContentType != "" {
+		return []string{baseMediaType(cu.ContentType)}
+	}
+	return []string{mimeJSON, mimeFormUrlencoded, mimeMultipart}
+}
+
+func baseMediaType(contentType string) string {
+	return strings.TrimSpace(strings.Split(contentType, ";")[0])
+}
+
+// reflectedAsJSON reports whether a request body with a custom content type, such as
+// application/xml, must be reflected as JSON to get a schema for its structure.
+//
+// The underlying reflector only documents JSON and form request bodies with a schema,
+// and falls back to a plain string for any other content type.
+func reflectedAsJSON(cu *specopenapi.ContentUnit) bool {
+	switch baseMediaType(cu.ContentType) {
+	case "", mimeJSON, mimeFormUrlencoded, mimeMultipart:
+		return false
+	}
+	if cu.Structure == nil {
+		return false
+	}
+	t := reflect.TypeOf(cu.Structure)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array:
+		return t.Elem().Kind() != reflect.Uint8
+	default:
+		return false
+	}
+}
+
+// orderRequests returns the request bodies reflected as JSON first, so moving them to
+// their own media type never replaces the schema of an actual JSON request body.
+func orderRequests(requests []*specopenapi.ContentUnit) []*specopenapi.ContentUnit {
+	ordered := make([]*specopenapi.ContentUnit, 0, len(requests))
+	for _, req := range requests {
+		if reflectedAsJSON(req) {
+			ordered = append(ordered, req)
+		}
+	}
+	for _, req := range requests {
+		if !reflectedAsJSON(req) {
+			ordered = append(ordered, req)
+		}
+	}
+	return ordered
+}
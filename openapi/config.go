@@ -1,6 +1,7 @@
 package openapi
 
 import (
+	"io/fs"
 	"reflect"
 
 	"github.com/oaswrap/spec-ui/config"
@@ -20,6 +21,10 @@ type Config struct {
 	SecuritySchemes map[string]*SecurityScheme // Security schemes available for the API.
 	Tags            []Tag                      // Tags used to organize operations.
 	ExternalDocs    *ExternalDocs              // Additional external documentation.
+	Fragments       []Fragment                 // Hand-written documents merged into the generated spec.
+	Components      Components                 // Reusable objects referenced by operations.
+
+	SpecTransformers []SpecTransformer // Functions modifying the document before it is checked and marshaled.
 
 	ReflectorConfig *ReflectorConfig // Configuration for schema reflection.
 
@@ -30,6 +35,8 @@ type Config struct {
 	Logger      Logger     // Logger for diagnostic output.
 	PathParser  PathParser // Path parser for framework-specific path conversions.
 
+	OperationIDFunc OperationIDFunc // Function generating the operation IDs that are not set explicitly.
+
 	UIProvider              config.Provider           // UI provider for the OpenAPI documentation.
 	SwaggerUIConfig         *config.SwaggerUI         // Configuration for embedded Swagger UI.
 	StoplightElementsConfig *config.StoplightElements // Configuration for Stoplight Elements.
@@ -38,6 +45,31 @@ type Config struct {
 	RapiDocConfig           *config.RapiDoc           // Configuration for RapiDoc.
 }
 
+// Fragment is a hand-written OpenAPI document merged into the generated specification.
+//
+// Either Data is set, or FS and Pattern select the files to read at build time.
+type Fragment struct {
+	Data    []byte // Raw YAML or JSON document.
+	FS      fs.FS  // File system containing the documents.
+	Pattern string // Glob pattern of the files to read from FS, as accepted by fs.Glob.
+}
+
+// Components holds reusable objects that operations reference by name.
+type Components struct {
+	// Responses maps names to reusable responses. Several content units with the same
+	// status describe several media types of one response.
+	Responses map[string][]*ContentUnit
+
+	// Parameters maps names to structures declaring a single query, header or cookie parameter.
+	Parameters map[string]any
+
+	// RequestBodies maps names to reusable request bodies, one content unit per media type.
+	RequestBodies map[string][]*ContentUnit
+
+	// Headers maps names to reusable response headers.
+	Headers map[string]Header
+}
+
 // ReflectorConfig holds advanced options for schema reflection.
 type ReflectorConfig struct {
 	InlineRefs           bool                 // If true, inline schema references instead of using components.
@@ -84,6 +116,16 @@ type InterceptSchemaParams struct {
 	Processed bool                       // True if the schema was already processed.
 }
 
+// OperationIDFunc returns the operation ID of an operation that does not set one.
+//
+// The method is upper-case, the path is the OpenAPI path template and operation is the
+// *option.OperationConfig of the operation. Use option.WithOperationIDFunc to set it.
+type OperationIDFunc func(method, path string, operation any) string
+
+// SpecTransformer modifies the built document, a *openapi3.Spec or *openapi31.Spec of
+// github.com/swaggest/openapi-go depending on the OpenAPI version.
+type SpecTransformer func(doc any) error
+
 // Logger defines an interface for logging diagnostic messages.
 type Logger interface {
 	Printf(format string, v ...any)
@@ -12,6 +12,44 @@ type ContentUnit struct {
 	Description string // Description provides a description for the content unit.
 
 	Encoding map[string]string // Encoding maps property names to content types
+
+	Links map[string]Link // Links maps link names to operations reachable from this response.
+
+	Headers          []Header // Headers lists response headers declared one by one.
+	HeaderStructures []any    // HeaderStructures holds structures whose `header` tagged fields are response headers.
+
+	HeaderRefs map[string]string // HeaderRefs maps response header names to header components.
+
+	Example  any                // Example is a sample payload of the media type.
+	Examples map[string]Example // Examples maps names to sample payloads of the media type.
+}
+
+// Example describes a named sample payload.
+// Generated from "#/$defs/example".
+type Example struct {
+	Summary       string // Short summary of the example.
+	Description   string // Example description.
+	Value         any    // Embedded sample value. Mutually exclusive with ExternalValue.
+	ExternalValue string // URI of the sample value. Format: uri-reference.
+}
+
+// Header describes a response header.
+type Header struct {
+	Name        string // Header name, such as "Location".
+	Schema      any    // Value whose type describes the header, such as "" or 0.
+	Description string // Header description.
+}
+
+// Link describes how values of a response can be used as input of another operation.
+// Generated from "#/$defs/link".
+type Link struct {
+	OperationID  string // Name of an existing operation. Mutually exclusive with OperationRef.
+	OperationRef string // URI reference to an operation. Format: uri-reference.
+
+	Parameters  map[string]any // Values or runtime expressions passed to the operation parameters.
+	RequestBody any            // Value or runtime expression used as the request body.
+
+	Description string // Link description.
 }
 
 // Contact represents contact information for the API.
@@ -80,10 +118,13 @@ type ServerVariable struct {
 // SecurityScheme describes a security scheme that can be used by operations.
 // Generated from "#/$defs/security-scheme".
 type SecurityScheme struct {
-	Description *string                   // Optional description.
-	APIKey      *SecuritySchemeAPIKey     // API key authentication scheme.
-	HTTPBearer  *SecuritySchemeHTTPBearer // HTTP Bearer authentication scheme.
-	OAuth2      *SecuritySchemeOAuth2     // OAuth2 authentication scheme.
+	Description   *string                      // Optional description.
+	APIKey        *SecuritySchemeAPIKey        // API key authentication scheme.
+	HTTP          *SecuritySchemeHTTP          // HTTP authentication scheme other than Bearer, such as Basic.
+	HTTPBearer    *SecuritySchemeHTTPBearer    // HTTP Bearer authentication scheme.
+	OAuth2        *SecuritySchemeOAuth2        // OAuth2 authentication scheme.
+	OpenIDConnect *SecuritySchemeOpenIDConnect // OpenID Connect authentication scheme.
+	MutualTLS     *SecuritySchemeMutualTLS     // Mutual TLS authentication scheme. Requires OpenAPI 3.1.
 
 	MapOfAnything map[string]any // Vendor extensions. Keys must match `^x-`.
 }
@@ -107,6 +148,12 @@ const (
 	SecuritySchemeAPIKeyInCookie = SecuritySchemeAPIKeyIn("cookie")
 )
 
+// SecuritySchemeHTTP defines HTTP authentication with a scheme other than Bearer.
+// Generated from "#/$defs/security-scheme/$defs/type-http".
+type SecuritySchemeHTTP struct {
+	Scheme string // Required. Name of the HTTP authentication scheme, such as "basic" or "digest".
+}
+
 // SecuritySchemeHTTPBearer defines HTTP Bearer authentication.
 // Generated from "#/$defs/security-scheme/$defs/type-http-bearer".
 type SecuritySchemeHTTPBearer struct {
@@ -120,6 +167,16 @@ type SecuritySchemeOAuth2 struct {
 	Flows OAuthFlows // Required. Supported OAuth2 flows.
 }
 
+// SecuritySchemeOpenIDConnect defines OpenID Connect authentication.
+// Generated from "#/$defs/security-scheme/$defs/type-oidc".
+type SecuritySchemeOpenIDConnect struct {
+	OpenIDConnectURL string // Required. OpenID Connect discovery URL. Format: uri.
+}
+
+// SecuritySchemeMutualTLS defines mutual TLS authentication.
+// Generated from "#/$defs/security-scheme/$defs/type-mutualTLS".
+type SecuritySchemeMutualTLS struct{}
+
 // OAuthFlows groups supported OAuth2 flows.
 // Generated from "#/$defs/oauth-flows".
 type OAuthFlows struct {
@@ -2,11 +2,14 @@ package spec
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/oaswrap/spec/internal/debuglog"
+	"github.com/oaswrap/spec/internal/mapper"
 	specopenapi "github.com/oaswrap/spec/openapi"
 	"github.com/oaswrap/spec/option"
+	"github.com/swaggest/jsonschema-go"
 	"github.com/swaggest/openapi-go"
 	"github.com/swaggest/openapi-go/openapi3"
 	"github.com/swaggest/openapi-go/openapi31"
@@ -15,9 +18,11 @@ import (
 var _ operationContext = (*operationContextImpl)(nil)
 
 type operationContextImpl struct {
-	op     openapi.OperationContext
-	cfg    *option.OperationConfig
-	logger *debuglog.Logger
+	op         openapi.OperationContext
+	cfg        *option.OperationConfig
+	logger     *debuglog.Logger
+	schemas    *jsonschema.Reflector
+	components *specopenapi.Components
 }
 
 func (oc *operationContextImpl) With(opts ...option.OperationOption) operationContext {
@@ -27,20 +32,25 @@ func (oc *operationContextImpl) With(opts ...option.OperationOption) operationCo
 	return oc
 }
 
-func (oc *operationContextImpl) build() openapi.OperationContext {
+func (oc *operationContextImpl) config() *option.OperationConfig {
+	return oc.cfg
+}
+
+func (oc *operationContextImpl) hidden() bool {
+	if !oc.cfg.Hide {
+		return false
+	}
+	oc.logger.LogAction("skip operation", fmt.Sprintf("%s %s", strings.ToUpper(oc.op.Method()), oc.op.PathPattern()))
+	return true
+}
+
+func (oc *operationContextImpl) build() (openapi.OperationContext, error) {
 	method := strings.ToUpper(oc.op.Method())
 	path := oc.op.PathPattern()
 
 	logger := oc.logger
 
 	cfg := oc.cfg
-	if cfg == nil {
-		return nil
-	}
-	if cfg.Hide {
-		logger.LogAction("skip operation", fmt.Sprintf("%s %s", method, path))
-		return nil
-	}
 	if cfg.Deprecated {
 		oc.op.SetIsDeprecated(true)
 		logger.LogOp(method, path, "set is deprecated", "true")
@@ -63,24 +73,37 @@ func (oc *operationContextImpl) build() openapi.OperationContext {
 	}
 	if len(cfg.Security) > 0 {
 		for _, sec := range cfg.Security {
-			oc.op.AddSecurity(sec.Name, sec.Scopes...)
+			oc.addSecurity(sec)
 		}
 		logger.LogOp(method, path, "set security", fmt.Sprintf("%v", cfg.Security))
+	} else if cfg.Security != nil {
+		oc.setPublic()
+		logger.LogOp(method, path, "set security", "public")
+	}
+
+	if err := oc.addRefs(method, path); err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, path, err)
 	}
 
-	for _, req := range cfg.Requests {
-		opts, value := oc.buildRequestOpts(req)
+	for _, req := range orderRequests(withGroupParams(cfg.GroupParams, cfg.Requests)) {
+		opts, value, err := oc.buildRequestOpts(req)
+		if err != nil {
+			return nil, fmt.Errorf("request of %s %s: %w", method, path, err)
+		}
 		oc.op.AddReqStructure(req.Structure, opts...)
 		logger.LogOp(method, path, "add request", value)
 	}
 
 	for _, resp := range cfg.Responses {
-		opts, value := oc.buildResponseOpts(resp)
+		opts, value, err := oc.buildResponseOpts(resp)
+		if err != nil {
+			return nil, fmt.Errorf("response %d of %s %s: %w", resp.HTTPStatus, method, path, err)
+		}
 		oc.op.AddRespStructure(resp.Structure, opts...)
 		logger.LogOp(method, path, "add response", value)
 	}
 
-	return oc.op
+	return oc.op, nil
 }
 
 func stringMapToEncodingMap3(enc map[string]string) map[string]openapi3.Encoding {
@@ -105,7 +128,9 @@ func stringMapToEncodingMap31(enc map[string]string) map[string]openapi31.Encodi
 	return res
 }
 
-func (oc *operationContextImpl) buildRequestOpts(req *specopenapi.ContentUnit) ([]openapi.ContentOption, string) {
+func (oc *operationContextImpl) buildRequestOpts(
+	req *specopenapi.ContentUnit,
+) ([]openapi.ContentOption, string, error) {
 	log := fmt.Sprintf("%T", req.Structure)
 	var opts []openapi.ContentOption
 	if req.Description != "" {
@@ -114,32 +139,68 @@ func (oc *operationContextImpl) buildRequestOpts(req *specopenapi.ContentUnit) (
 		})
 		log += fmt.Sprintf(" (%s)", req.Description)
 	}
-	if req.ContentType != "" {
+	asJSON := reflectedAsJSON(req)
+	switch {
+	case asJSON:
+		// Reflected as JSON, then moved to its own media type by the customizer below.
+		opts = append(opts, openapi.WithContentType(mimeJSON))
+		log += fmt.Sprintf(" (Content-Type: %s)", req.ContentType)
+	case req.ContentType != "":
 		opts = append(opts, openapi.WithContentType(req.ContentType))
 		log += fmt.Sprintf(" (Content-Type: %s)", req.ContentType)
 	}
+	examples, err := newContentExamples(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if !examples.empty() {
+		log += " (examples)"
+	}
+	mediaTypes := contentMediaTypes(req)
 	opts = append(opts, func(cu *openapi.ContentUnit) {
 		cu.Customize = func(cor openapi.ContentOrReference) {
 			switch v := cor.(type) {
 			case *openapi3.RequestBodyOrRef:
-				content := map[string]openapi3.MediaType{}
-				for k, val := range v.RequestBody.Content {
-					content[k] = *val.WithEncoding(stringMapToEncodingMap3(req.Encoding))
+				if oc.cfg.RequestBodyRef != "" {
+					v.RequestBody = nil // replaced by the referenced component
+					return
 				}
-				v.RequestBody.WithContent(content)
+				content := v.RequestBody.Content
+				if val, ok := content[mimeJSON]; asJSON && ok {
+					content[mediaTypes[0]] = val
+					delete(content, mimeJSON)
+				}
+				for _, mt := range mediaTypes {
+					if val, ok := content[mt]; ok {
+						content[mt] = *val.WithEncoding(stringMapToEncodingMap3(req.Encoding))
+					}
+				}
+				examples.oas3(content)
 			case *openapi31.RequestBodyOrReference:
-				content := map[string]openapi31.MediaType{}
-				for k, val := range v.RequestBody.Content {
-					content[k] = *val.WithEncoding(stringMapToEncodingMap31(req.Encoding))
+				if oc.cfg.RequestBodyRef != "" {
+					v.RequestBody = nil // replaced by the referenced component
+					return
+				}
+				content := v.RequestBody.Content
+				if val, ok := content[mimeJSON]; asJSON && ok {
+					content[mediaTypes[0]] = val
+					delete(content, mimeJSON)
+				}
+				for _, mt := range mediaTypes {
+					if val, ok := content[mt]; ok {
+						content[mt] = *val.WithEncoding(stringMapToEncodingMap31(req.Encoding))
+					}
 				}
-				v.RequestBody.WithContent(content)
+				examples.oas31(content)
 			}
 		}
 	})
-	return opts, log
+	return opts, log, nil
 }
 
-func (oc *operationContextImpl) buildResponseOpts(resp *specopenapi.ContentUnit) ([]openapi.ContentOption, string) {
+func (oc *operationContextImpl) buildResponseOpts(
+	resp *specopenapi.ContentUnit,
+) ([]openapi.ContentOption, string, error) {
 	log := fmt.Sprintf("%T", resp.Structure)
 	var opts []openapi.ContentOption
 	if resp.IsDefault {
@@ -162,5 +223,62 @@ func (oc *operationContextImpl) buildResponseOpts(resp *specopenapi.ContentUnit)
 		opts = append(opts, openapi.WithContentType(resp.ContentType))
 		log += fmt.Sprintf(" (Content-Type: %s)", resp.ContentType)
 	}
-	return opts, log
+
+	var customizers []func(cor openapi.ContentOrReference)
+	if len(resp.Links) > 0 {
+		customizers = append(customizers, func(cor openapi.ContentOrReference) {
+			switch v := cor.(type) {
+			case *openapi3.ResponseOrRef:
+				v.Response.WithLinks(mapper.OAS3Links(resp.Links))
+			case *openapi31.ResponseOrReference:
+				v.Response.WithLinks(mapper.OAS31Links(resp.Links))
+			}
+		})
+		log += fmt.Sprintf(" (links: %s)", strings.Join(sortedKeys(resp.Links), ", "))
+	}
+	if len(resp.Headers) > 0 || len(resp.HeaderStructures) > 0 {
+		headers, err := reflectResponseHeaders(oc.schemas, resp)
+		if err != nil {
+			return nil, "", err
+		}
+		customizers = append(customizers, customizeResponseHeaders(headers))
+		names := make([]string, 0, len(headers))
+		for _, h := range headers {
+			names = append(names, h.name)
+		}
+		log += fmt.Sprintf(" (headers: %s)", strings.Join(names, ", "))
+	}
+	if len(resp.HeaderRefs) > 0 {
+		refs, err := oc.headerRefs(resp)
+		if err != nil {
+			return nil, "", err
+		}
+		customizers = append(customizers, customizeResponseHeaderRefs(refs))
+		log += fmt.Sprintf(" (header refs: %s)", strings.Join(sortedKeys(refs), ", "))
+	}
+	examples, err := newContentExamples(resp)
+	if err != nil {
+		return nil, "", err
+	}
+	if !examples.empty() {
+		customizers = append(customizers, examples.customizeResponse)
+		log += " (examples)"
+	}
+	if len(customizers) > 0 {
+		opts = append(opts, openapi.WithCustomize(func(cor openapi.ContentOrReference) {
+			for _, customize := range customizers {
+				customize(cor)
+			}
+		}))
+	}
+	return opts, log, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
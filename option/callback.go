@@ -0,0 +1,100 @@
+package option
+
+import "net/http"
+
+// CallbackConfig holds the operations of an OpenAPI callback.
+type CallbackConfig struct {
+	Name       string // Name of the callback, such as "onComplete".
+	Expression string // Runtime expression of the callback URL, such as "{$request.body#/callbackUrl}".
+	Operations []CallbackOperationConfig
+}
+
+// CallbackOperationConfig describes a request the API sends to the callback URL.
+type CallbackOperationConfig struct {
+	Method  string
+	Options []OperationOption
+}
+
+// CallbackRouter registers the operations of a callback.
+//
+// Operations are described with the same options as routes: option.Request for the
+// payload the API sends, and option.Response for what the receiver should return.
+type CallbackRouter interface {
+	// Get registers a GET request to the callback URL.
+	Get(opts ...OperationOption)
+
+	// Post registers a POST request to the callback URL.
+	Post(opts ...OperationOption)
+
+	// Put registers a PUT request to the callback URL.
+	Put(opts ...OperationOption)
+
+	// Delete registers a DELETE request to the callback URL.
+	Delete(opts ...OperationOption)
+
+	// Patch registers a PATCH request to the callback URL.
+	Patch(opts ...OperationOption)
+
+	// Add registers a request with the given HTTP method to the callback URL.
+	Add(method string, opts ...OperationOption)
+}
+
+// Callback adds an OpenAPI callback to the operation.
+//
+// The expression is evaluated at runtime to get the URL the API calls back,
+// and fn registers the requests sent to that URL.
+//
+// Example:
+//
+//	r.Post("/jobs",
+//	    option.Request(new(CreateJob)),
+//	    option.Callback("onComplete", "{$request.body#/callbackUrl}", func(cb option.CallbackRouter) {
+//	        cb.Post(
+//	            option.Request(new(JobResult)),
+//	            option.Response(204, nil),
+//	        )
+//	    }),
+//	)
+func Callback(name, expression string, fn func(r CallbackRouter)) OperationOption {
+	return func(cfg *OperationConfig) {
+		cb := &callbackRouter{cfg: CallbackConfig{
+			Name:       name,
+			Expression: expression,
+		}}
+		if fn != nil {
+			fn(cb)
+		}
+		cfg.Callbacks = append(cfg.Callbacks, cb.cfg)
+	}
+}
+
+type callbackRouter struct {
+	cfg CallbackConfig
+}
+
+func (r *callbackRouter) Get(opts ...OperationOption) {
+	r.Add(http.MethodGet, opts...)
+}
+
+func (r *callbackRouter) Post(opts ...OperationOption) {
+	r.Add(http.MethodPost, opts...)
+}
+
+func (r *callbackRouter) Put(opts ...OperationOption) {
+	r.Add(http.MethodPut, opts...)
+}
+
+func (r *callbackRouter) Delete(opts ...OperationOption) {
+	r.Add(http.MethodDelete, opts...)
+}
+
+func (r *callbackRouter) Patch(opts ...OperationOption) {
+	r.Add(http.MethodPatch, opts...)
+}
+
+func (r *callbackRouter) Add(method string, opts ...OperationOption) {
+	r.cfg.Operations = append(r.cfg.Operations, CallbackOperationConfig{
+		Method:  method,
+		Options: opts,
+	})
+}
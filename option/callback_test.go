@@ -0,0 +1,42 @@
+package option_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/oaswrap/spec/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallback(t *testing.T) {
+	cfg := &option.OperationConfig{}
+	option.Callback("onEvent", "{$request.body#/callbackUrl}", func(cb option.CallbackRouter) {
+		cb.Get(option.Summary("get"))
+		cb.Post(option.Summary("post"))
+		cb.Put(option.Summary("put"))
+		cb.Delete(option.Summary("delete"))
+		cb.Patch(option.Summary("patch"))
+		cb.Add(http.MethodHead)
+	})(cfg)
+	option.Callback("onCancel", "{$request.query.cancelUrl}", nil)(cfg)
+
+	require.Len(t, cfg.Callbacks, 2)
+	assert.Equal(t, "onEvent", cfg.Callbacks[0].Name)
+	assert.Equal(t, "{$request.body#/callbackUrl}", cfg.Callbacks[0].Expression)
+
+	methods := make([]string, 0, len(cfg.Callbacks[0].Operations))
+	for _, op := range cfg.Callbacks[0].Operations {
+		methods = append(methods, op.Method)
+	}
+	assert.Equal(t, []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD"}, methods)
+
+	opCfg := &option.OperationConfig{}
+	for _, opt := range cfg.Callbacks[0].Operations[1].Options {
+		opt(opCfg)
+	}
+	assert.Equal(t, "post", opCfg.Summary)
+
+	assert.Equal(t, "onCancel", cfg.Callbacks[1].Name)
+	assert.Empty(t, cfg.Callbacks[1].Operations)
+}
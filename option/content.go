@@ -37,3 +37,159 @@ func ContentEncoding(prop, enc string) ContentOption {
 		cu.Encoding[prop] = enc
 	}
 }
+
+// ContentExample sets a sample payload for the content.
+//
+// Struct values are marshaled with the same JSON rules as the schema.
+//
+// Example:
+//
+//	option.Response(200, new(Pet),
+//	    option.ContentExample(Pet{ID: 1, Name: "Rex"}),
+//	)
+func ContentExample(value any) ContentOption {
+	return func(cu *openapi.ContentUnit) {
+		cu.Example = value
+	}
+}
+
+// ContentExamples adds named sample payloads to the content.
+//
+// Example:
+//
+//	option.Request(new(CreatePet),
+//	    option.ContentExamples(map[string]openapi.Example{
+//	        "dog": {Summary: "A dog", Value: CreatePet{Name: "Rex"}},
+//	        "cat": {Summary: "A cat", Value: CreatePet{Name: "Tom"}},
+//	    }),
+//	)
+func ContentExamples(examples map[string]openapi.Example) ContentOption {
+	return func(cu *openapi.ContentUnit) {
+		if cu.Examples == nil {
+			cu.Examples = map[string]openapi.Example{}
+		}
+		for name, example := range examples {
+			cu.Examples[name] = example
+		}
+	}
+}
+
+// LinkOption is a function that modifies a response Link.
+type LinkOption func(link *openapi.Link)
+
+// ResponseLink adds a link from the response to another operation.
+//
+// Example:
+//
+//	option.Response(201, new(Pet),
+//	    option.ResponseLink("GetPet",
+//	        option.LinkOperationID("getPet"),
+//	        option.LinkParam("petId", "$response.body#/id"),
+//	    ),
+//	)
+func ResponseLink(name string, opts ...LinkOption) ContentOption {
+	return func(cu *openapi.ContentUnit) {
+		link := openapi.Link{}
+		for _, opt := range opts {
+			opt(&link)
+		}
+		if cu.Links == nil {
+			cu.Links = map[string]openapi.Link{}
+		}
+		cu.Links[name] = link
+	}
+}
+
+// LinkOperationID sets the operation ID of the linked operation.
+//
+// Validate reports an error if no registered route has this operation ID.
+func LinkOperationID(operationID string) LinkOption {
+	return func(link *openapi.Link) {
+		link.OperationID = operationID
+	}
+}
+
+// LinkOperationRef sets a URI reference to the linked operation, such as
+// "#/paths/~1pets~1{petId}/get".
+func LinkOperationRef(operationRef string) LinkOption {
+	return func(link *openapi.Link) {
+		link.OperationRef = operationRef
+	}
+}
+
+// LinkParam passes a value or runtime expression, such as "$response.body#/id",
+// to a parameter of the linked operation.
+func LinkParam(name string, value any) LinkOption {
+	return func(link *openapi.Link) {
+		if link.Parameters == nil {
+			link.Parameters = map[string]any{}
+		}
+		link.Parameters[name] = value
+	}
+}
+
+// LinkRequestBody sets a value or runtime expression used as the request body
+// of the linked operation.
+func LinkRequestBody(value any) LinkOption {
+	return func(link *openapi.Link) {
+		link.RequestBody = value
+	}
+}
+
+// LinkDescription sets the description of the link.
+func LinkDescription(description string) LinkOption {
+	return func(link *openapi.Link) {
+		link.Description = description
+	}
+}
+
+// ResponseHeader adds a header to the response.
+//
+// The schema is a value whose type describes the header, such as "" or 0.
+//
+// Example:
+//
+//	option.Response(201, new(Pet),
+//	    option.ResponseHeader("Location", "", "URL of the created pet"),
+//	)
+func ResponseHeader(name string, schema any, description string) ContentOption {
+	return func(cu *openapi.ContentUnit) {
+		cu.Headers = append(cu.Headers, openapi.Header{
+			Name:        name,
+			Schema:      schema,
+			Description: description,
+		})
+	}
+}
+
+// ResponseHeaders adds the fields of a structure tagged with `header` as headers of the response.
+//
+// Fields support the same tags as request headers, such as description, required and format.
+//
+// Example:
+//
+//	type RateLimitHeaders struct {
+//	    Limit     int `header:"X-RateLimit-Limit" description:"Requests allowed per window"`
+//	    Remaining int `header:"X-RateLimit-Remaining" required:"true"`
+//	}
+//
+//	option.Response(429, new(Error), option.ResponseHeaders(new(RateLimitHeaders)))
+func ResponseHeaders(structure any) ContentOption {
+	return func(cu *openapi.ContentUnit) {
+		cu.HeaderStructures = append(cu.HeaderStructures, structure)
+	}
+}
+
+// ResponseHeaderRef adds a header defined with option.WithComponentHeader to the response.
+//
+// Example:
+//
+//	option.Response(429, nil, option.ResponseHeaderRef("Retry-After", "RetryAfter"))
+func ResponseHeaderRef(name, component string) ContentOption {
+	return func(cu *openapi.ContentUnit) {
+		if cu.HeaderRefs == nil {
+			cu.HeaderRefs = map[string]string{}
+		}
+		cu.HeaderRefs[name] = component
+	}
+}
@@ -71,6 +71,88 @@ func TestContentOption(t *testing.T) {
 				IsDefault:   true,
 			},
 		},
+		{
+			name:       "with links",
+			httpStatus: 201,
+			opts: []option.ContentOption{
+				option.ResponseLink("GetPet",
+					option.LinkOperationID("getPet"),
+					option.LinkParam("petId", "$response.body#/id"),
+					option.LinkDescription("Get the created pet"),
+				),
+				option.ResponseLink("UpdatePet",
+					option.LinkOperationRef("#/paths/~1pets~1{petId}/patch"),
+					option.LinkParam("petId", "$response.body#/id"),
+					option.LinkRequestBody("$request.body"),
+				),
+			},
+			expected: openapi.ContentUnit{
+				HTTPStatus: 201,
+				Links: map[string]openapi.Link{
+					"GetPet": {
+						OperationID: "getPet",
+						Parameters:  map[string]any{"petId": "$response.body#/id"},
+						Description: "Get the created pet",
+					},
+					"UpdatePet": {
+						OperationRef: "#/paths/~1pets~1{petId}/patch",
+						Parameters:   map[string]any{"petId": "$response.body#/id"},
+						RequestBody:  "$request.body",
+					},
+				},
+			},
+		},
+		{
+			name:       "with examples",
+			httpStatus: 200,
+			opts: []option.ContentOption{
+				option.ContentExample(map[string]any{"id": 1}),
+				option.ContentExamples(map[string]openapi.Example{
+					"dog": {Summary: "A dog", Value: map[string]any{"name": "Rex"}},
+				}),
+				option.ContentExamples(map[string]openapi.Example{
+					"remote": {ExternalValue: "https://example.com/pet.json"},
+				}),
+			},
+			expected: openapi.ContentUnit{
+				HTTPStatus: 200,
+				Example:    map[string]any{"id": 1},
+				Examples: map[string]openapi.Example{
+					"dog":    {Summary: "A dog", Value: map[string]any{"name": "Rex"}},
+					"remote": {ExternalValue: "https://example.com/pet.json"},
+				},
+			},
+		},
+		{
+			name:       "with header refs",
+			httpStatus: 429,
+			opts: []option.ContentOption{
+				option.ResponseHeaderRef("Retry-After", "RetryAfter"),
+			},
+			expected: openapi.ContentUnit{
+				HTTPStatus: 429,
+				HeaderRefs: map[string]string{"Retry-After": "RetryAfter"},
+			},
+		},
+		{
+			name:       "with headers",
+			httpStatus: 201,
+			opts: []option.ContentOption{
+				option.ResponseHeader("Location", "", "URL of the created pet"),
+				option.ResponseHeaders(new(struct {
+					Limit int `header:"X-RateLimit-Limit"`
+				})),
+			},
+			expected: openapi.ContentUnit{
+				HTTPStatus: 201,
+				Headers: []openapi.Header{
+					{Name: "Location", Schema: "", Description: "URL of the created pet"},
+				},
+				HeaderStructures: []any{new(struct {
+					Limit int `header:"X-RateLimit-Limit"`
+				})},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
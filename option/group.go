@@ -8,6 +8,10 @@ type GroupConfig struct {
 	Security   []OperationSecurityConfig
 	Deprecated bool
 	Hide       bool
+
+	Options           []OperationOption // Operation options applied to every route, before its own options.
+	SummaryPrefix     string            // Prefix added to the summary of every route.
+	OperationIDPrefix string            // Prefix added to the operation ID of every route.
 }
 
 // GroupOption applies a configuration option to a GroupConfig.
@@ -24,7 +28,8 @@ func GroupTags(tags ...string) GroupOption {
 
 // GroupSecurity adds a security scheme to the group.
 //
-// The security scheme will apply to all routes in the sub-router.
+// The security scheme will apply to all routes in the sub-router. A route can remove it
+// with NoSecurity.
 func GroupSecurity(securityName string, scopes ...string) GroupOption {
 	return func(cfg *GroupConfig) {
 		cfg.Security = append(cfg.Security, OperationSecurityConfig{
@@ -34,6 +39,26 @@ func GroupSecurity(securityName string, scopes ...string) GroupOption {
 	}
 }
 
+// GroupSecurityAll adds a security requirement combining several schemes to the group.
+//
+// The requirement will apply to all routes in the sub-router. See SecurityAll.
+func GroupSecurityAll(requirements ...OperationSecurityConfig) GroupOption {
+	return func(cfg *GroupConfig) {
+		cfg.Security = append(cfg.Security, OperationSecurityConfig{
+			All: requirements,
+		})
+	}
+}
+
+// GroupSecurityOptional makes the security of the group optional.
+//
+// The routes in the sub-router can also be called without satisfying the other requirements.
+func GroupSecurityOptional() GroupOption {
+	return func(cfg *GroupConfig) {
+		cfg.Security = append(cfg.Security, OperationSecurityConfig{})
+	}
+}
+
 // GroupHidden sets whether the group should be hidden.
 //
 // If true, the group and its routes will be excluded from the OpenAPI output.
@@ -51,3 +76,58 @@ func GroupDeprecated(deprecated ...bool) GroupOption {
 		cfg.Deprecated = util.Optional(true, deprecated...)
 	}
 }
+
+// GroupOperationOptions adds operation options to the group.
+//
+// The options apply to all routes in the sub-router, before the options of each route,
+// so a route can still override single values such as the summary.
+func GroupOperationOptions(opts ...OperationOption) GroupOption {
+	return func(cfg *GroupConfig) {
+		cfg.Options = append(cfg.Options, opts...)
+	}
+}
+
+// GroupRequest adds a request structure to all routes in the group.
+//
+// It is typically used for parameters shared by the routes, such as common headers,
+// and is combined with the request structure of each route.
+func GroupRequest(structure any, opts ...ContentOption) GroupOption {
+	return GroupOperationOptions(Request(structure, opts...))
+}
+
+// GroupResponse adds a response to all routes in the group.
+//
+// It is typically used for common error responses, such as 401 or 500.
+func GroupResponse(httpStatus int, structure any, opts ...ContentOption) GroupOption {
+	return GroupOperationOptions(Response(httpStatus, structure, opts...))
+}
+
+// GroupParams declares parameter structures for all routes in the group.
+//
+// It is typically used for parameters of the group prefix, such as "/tenants/{tenantId}",
+// so the request structures of the routes do not need to declare them. A structure is
+// skipped for routes whose request structures already declare all of its parameters.
+func GroupParams(structures ...any) GroupOption {
+	return GroupOperationOptions(func(cfg *OperationConfig) {
+		cfg.GroupParams = append(cfg.GroupParams, structures...)
+	})
+}
+
+// GroupSummaryPrefix adds a prefix to the summary of all routes in the group.
+//
+// Prefixes of nested groups are joined from the outermost group inwards.
+func GroupSummaryPrefix(prefix string) GroupOption {
+	return func(cfg *GroupConfig) {
+		cfg.SummaryPrefix += prefix
+	}
+}
+
+// GroupOperationIDPrefix adds a prefix to the operation ID of all routes in the group,
+// including the IDs generated by the function set with WithOperationIDFunc.
+//
+// Prefixes of nested groups are joined from the outermost group inwards.
+func GroupOperationIDPrefix(prefix string) GroupOption {
+	return func(cfg *GroupConfig) {
+		cfg.OperationIDPrefix += prefix
+	}
+}
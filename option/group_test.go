@@ -73,6 +73,17 @@ func TestGroupSecurity(t *testing.T) {
 	})
 }
 
+func TestGroupSecurityAll(t *testing.T) {
+	cfg := &option.GroupConfig{}
+	option.GroupSecurityAll(option.SecurityRequirement("apiKey"), option.SecurityRequirement("bearer"))(cfg)
+	option.GroupSecurityOptional()(cfg)
+
+	assert.Equal(t, []option.OperationSecurityConfig{
+		{All: []option.OperationSecurityConfig{{Name: "apiKey"}, {Name: "bearer"}}},
+		{},
+	}, cfg.Security)
+}
+
 func TestGroupHidden(t *testing.T) {
 	t.Run("hides route by default", func(t *testing.T) {
 		cfg := &option.GroupConfig{}
@@ -140,3 +151,49 @@ func TestGroupDeprecated(t *testing.T) {
 		assert.False(t, cfg.Deprecated)
 	})
 }
+
+func TestGroupOperationOptions(t *testing.T) {
+	t.Run("adds operation options", func(t *testing.T) {
+		cfg := &option.GroupConfig{}
+		option.GroupOperationOptions(option.Summary("summary"))(cfg)
+		option.GroupRequest(struct{}{})(cfg)
+		option.GroupResponse(401, nil, option.ContentDescription("Unauthorized"))(cfg)
+
+		assert.Len(t, cfg.Options, 3)
+		opCfg := &option.OperationConfig{}
+		for _, opt := range cfg.Options {
+			opt(opCfg)
+		}
+		assert.Equal(t, "summary", opCfg.Summary)
+		assert.Len(t, opCfg.Requests, 1)
+		assert.Len(t, opCfg.Responses, 1)
+		assert.Equal(t, 401, opCfg.Responses[0].HTTPStatus)
+		assert.Equal(t, "Unauthorized", opCfg.Responses[0].Description)
+	})
+
+	t.Run("joins prefixes", func(t *testing.T) {
+		cfg := &option.GroupConfig{}
+		option.GroupSummaryPrefix("Admin: ")(cfg)
+		option.GroupOperationIDPrefix("admin")(cfg)
+		option.GroupOperationIDPrefix("Users")(cfg)
+
+		assert.Equal(t, "Admin: ", cfg.SummaryPrefix)
+		assert.Equal(t, "adminUsers", cfg.OperationIDPrefix)
+	})
+}
+
+func TestGroupParams(t *testing.T) {
+	type TenantPath struct {
+		TenantID string `path:"tenantId"`
+	}
+	cfg := &option.GroupConfig{}
+	option.GroupParams(new(TenantPath))(cfg)
+	option.GroupParams(new(TenantPath))(cfg)
+
+	opCfg := &option.OperationConfig{}
+	for _, opt := range cfg.Options {
+		opt(opCfg)
+	}
+	assert.Equal(t, []any{new(TenantPath), new(TenantPath)}, opCfg.GroupParams)
+	assert.Empty(t, opCfg.Requests)
+}
@@ -1,11 +1,14 @@
 package option
 
 import (
+	"io/fs"
 	"log" //nolint:depguard // Use standard log package for simplicity.
 
 	"github.com/oaswrap/spec-ui/config"
 	"github.com/oaswrap/spec/openapi"
 	"github.com/oaswrap/spec/pkg/util"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
 )
 
 // OpenAPIOption defines a function that applies configuration to an OpenAPI Config.
@@ -117,7 +120,8 @@ func WithExternalDocs(url string, description ...string) OpenAPIOption {
 
 // WithSecurity adds a security scheme to the OpenAPI documentation.
 //
-// It can be used to define API key or HTTP Bearer authentication schemes.
+// It can be used to define API key, HTTP, OAuth2, OpenID Connect or mutual TLS
+// authentication schemes.
 func WithSecurity(name string, opts ...SecurityOption) OpenAPIOption {
 	return func(c *openapi.Config) {
 		securityConfig := &securityConfig{}
@@ -134,6 +138,11 @@ func WithSecurity(name string, opts ...SecurityOption) OpenAPIOption {
 				Description: securityConfig.Description,
 				APIKey:      securityConfig.APIKey,
 			}
+		case securityConfig.HTTP != nil:
+			c.SecuritySchemes[name] = &openapi.SecurityScheme{
+				Description: securityConfig.Description,
+				HTTP:        securityConfig.HTTP,
+			}
 		case securityConfig.HTTPBearer != nil:
 			c.SecuritySchemes[name] = &openapi.SecurityScheme{
 				Description: securityConfig.Description,
@@ -144,10 +153,186 @@ func WithSecurity(name string, opts ...SecurityOption) OpenAPIOption {
 				Description: securityConfig.Description,
 				OAuth2:      securityConfig.Oauth2,
 			}
+		case securityConfig.OpenIDConnect != nil:
+			c.SecuritySchemes[name] = &openapi.SecurityScheme{
+				Description:   securityConfig.Description,
+				OpenIDConnect: securityConfig.OpenIDConnect,
+			}
+		case securityConfig.MutualTLS != nil:
+			c.SecuritySchemes[name] = &openapi.SecurityScheme{
+				Description: securityConfig.Description,
+				MutualTLS:   securityConfig.MutualTLS,
+			}
+		}
+	}
+}
+
+// WithComponentResponse adds a reusable response to the components of the specification.
+//
+// Operations reference it with ResponseRef, under httpStatus unless overridden. Adding
+// the same name again with another content type describes one more media type.
+//
+// Example:
+//
+//	option.WithComponentResponse("NotFound", 404, new(ErrorBody),
+//	    option.ContentDescription("Resource not found"),
+//	)
+func WithComponentResponse(name string, httpStatus int, structure any, opts ...ContentOption) OpenAPIOption {
+	return func(c *openapi.Config) {
+		cu := &openapi.ContentUnit{
+			HTTPStatus: httpStatus,
+			Structure:  structure,
+		}
+		for _, opt := range opts {
+			opt(cu)
+		}
+		if c.Components.Responses == nil {
+			c.Components.Responses = map[string][]*openapi.ContentUnit{}
+		}
+		c.Components.Responses[name] = append(c.Components.Responses[name], cu)
+	}
+}
+
+// WithComponentParameter adds a reusable parameter to the components of the specification.
+//
+// The structure declares exactly one query, header or cookie parameter. Operations
+// reference it with ParameterRef.
+//
+// Example:
+//
+//	type PageParam struct {
+//	    Page int `query:"page" minimum:"1" default:"1"`
+//	}
+//
+//	option.WithComponentParameter("Page", new(PageParam))
+func WithComponentParameter(name string, structure any) OpenAPIOption {
+	return func(c *openapi.Config) {
+		if c.Components.Parameters == nil {
+			c.Components.Parameters = map[string]any{}
+		}
+		c.Components.Parameters[name] = structure
+	}
+}
+
+// WithComponentRequestBody adds a reusable request body to the components of the specification.
+//
+// Operations reference it with RequestBodyRef. Adding the same name again with another
+// content type describes one more media type.
+func WithComponentRequestBody(name string, structure any, opts ...ContentOption) OpenAPIOption {
+	return func(c *openapi.Config) {
+		cu := &openapi.ContentUnit{
+			Structure: structure,
+		}
+		for _, opt := range opts {
+			opt(cu)
+		}
+		if c.Components.RequestBodies == nil {
+			c.Components.RequestBodies = map[string][]*openapi.ContentUnit{}
+		}
+		c.Components.RequestBodies[name] = append(c.Components.RequestBodies[name], cu)
+	}
+}
+
+// WithComponentHeader adds a reusable response header to the components of the specification.
+//
+// The schema is a value whose type describes the header, such as "" or 0. Responses
+// reference it with ResponseHeaderRef.
+func WithComponentHeader(name string, schema any, description string) OpenAPIOption {
+	return func(c *openapi.Config) {
+		if c.Components.Headers == nil {
+			c.Components.Headers = map[string]openapi.Header{}
+		}
+		c.Components.Headers[name] = openapi.Header{
+			Name:        name,
+			Schema:      schema,
+			Description: description,
 		}
 	}
 }
 
+// WithFragment merges a hand-written OpenAPI document into the generated specification.
+//
+// The document may be YAML or JSON and only needs the sections to merge, such as
+// legacy "paths", shared "components" or extra "tags". Conflicts with the generated
+// specification, like an operation defined both by a route and by the fragment,
+// are reported by Validate.
+func WithFragment(data []byte) OpenAPIOption {
+	return func(c *openapi.Config) {
+		c.Fragments = append(c.Fragments, openapi.Fragment{Data: data})
+	}
+}
+
+// WithFragmentFS merges the OpenAPI documents matching the glob patterns in fsys
+// into the generated specification.
+//
+// The files are read when the specification is built; see WithFragment for the merge rules.
+//
+// Example:
+//
+//	//go:embed openapi/*.yaml
+//	var fragments embed.FS
+//
+//	opt := option.WithFragmentFS(fragments, "openapi/*.yaml")
+func WithFragmentFS(fsys fs.FS, patterns ...string) OpenAPIOption {
+	return func(c *openapi.Config) {
+		for _, pattern := range patterns {
+			c.Fragments = append(c.Fragments, openapi.Fragment{FS: fsys, Pattern: pattern})
+		}
+	}
+}
+
+// WithSpecTransformer adds a function that modifies the built document, for example to add
+// "x-" extensions or patch a schema the options cannot describe.
+//
+// Transformers run in the order they are added, once all operations, webhooks and
+// fragments are in the document and before it is checked and marshaled. The document
+// is a *openapi3.Spec or *openapi31.Spec depending on the OpenAPI version; see
+// WithSpecTransformer3 and WithSpecTransformer31 for typed variants. An error returned
+// by a transformer is reported by Validate.
+//
+// Transformers may use the generator, for example to list its operations, but must not
+// marshal or validate it, or call its Document method, as that builds the spec again.
+//
+// Example:
+//
+//	opt := option.WithSpecTransformer(func(doc any) error {
+//		if s, ok := doc.(*openapi31.Spec); ok {
+//			s.WithMapOfAnythingItem("x-api-id", "pets")
+//		}
+//		return nil
+//	})
+func WithSpecTransformer(fn func(doc any) error) OpenAPIOption {
+	return func(c *openapi.Config) {
+		c.SpecTransformers = append(c.SpecTransformers, fn)
+	}
+}
+
+// WithSpecTransformer3 adds a transformer for OpenAPI 3.0 documents.
+// It is skipped when another OpenAPI version is configured.
+//
+// See WithSpecTransformer for when transformers run.
+func WithSpecTransformer3(fn func(doc *openapi3.Spec) error) OpenAPIOption {
+	return WithSpecTransformer(func(doc any) error {
+		if s, ok := doc.(*openapi3.Spec); ok {
+			return fn(s)
+		}
+		return nil
+	})
+}
+
+// WithSpecTransformer31 adds a transformer for OpenAPI 3.1 documents.
+// It is skipped when another OpenAPI version is configured.
+//
+// See WithSpecTransformer for when transformers run.
+func WithSpecTransformer31(fn func(doc *openapi31.Spec) error) OpenAPIOption {
+	return WithSpecTransformer(func(doc any) error {
+		if s, ok := doc.(*openapi31.Spec); ok {
+			return fn(s)
+		}
+		return nil
+	})
+}
+
 // WithReflectorConfig applies custom configurations to the OpenAPI reflector.
 func WithReflectorConfig(opts ...ReflectorOption) OpenAPIOption {
 	return func(c *openapi.Config) {
@@ -308,6 +493,28 @@ func WithPathParser(parser openapi.PathParser) OpenAPIOption {
 	}
 }
 
+// WithOperationIDFunc sets the function generating the operation IDs that are not set
+// explicitly with OperationID.
+//
+// The package provides OperationIDCamelCase and OperationIDDotted, or any function can
+// derive the ID from the method, the OpenAPI path template and the operation config.
+//
+// Example:
+//
+//	opt := option.WithOperationIDFunc(option.OperationIDCamelCase)
+func WithOperationIDFunc(fn OperationIDFunc) OpenAPIOption {
+	return func(c *openapi.Config) {
+		if fn == nil {
+			c.OperationIDFunc = nil
+			return
+		}
+		c.OperationIDFunc = func(method, path string, operation any) string {
+			cfg, _ := operation.(*OperationConfig)
+			return fn(method, path, cfg)
+		}
+	}
+}
+
 type noopLogger struct{}
 
 func (l noopLogger) Printf(_ string, _ ...any) {}
@@ -1,7 +1,9 @@
 package option_test
 
 import (
+	"errors"
 	"testing"
+	"testing/fstest"
 
 	"github.com/oaswrap/spec-ui/config"
 	"github.com/oaswrap/spec/openapi"
@@ -9,6 +11,8 @@ import (
 	"github.com/oaswrap/spec/pkg/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
 )
 
 func TestWithOpenAPIConfig(t *testing.T) {
@@ -310,6 +314,54 @@ func TestWithSecurity(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "HTTP Basic Scheme",
+			scheme: "basicAuth",
+			opts: []option.SecurityOption{
+				option.SecurityHTTPBasic(),
+				option.SecurityDescription("Basic authentication"),
+			},
+			expected: &openapi.SecurityScheme{
+				Description: util.PtrOf("Basic authentication"),
+				HTTP: &openapi.SecuritySchemeHTTP{
+					Scheme: "basic",
+				},
+			},
+		},
+		{
+			name:   "HTTP Digest Scheme",
+			scheme: "digestAuth",
+			opts: []option.SecurityOption{
+				option.SecurityHTTP("digest"),
+			},
+			expected: &openapi.SecurityScheme{
+				HTTP: &openapi.SecuritySchemeHTTP{
+					Scheme: "digest",
+				},
+			},
+		},
+		{
+			name:   "OpenID Connect Scheme",
+			scheme: "oidc",
+			opts: []option.SecurityOption{
+				option.SecurityOpenIDConnect("https://auth.example.com/.well-known/openid-configuration"),
+			},
+			expected: &openapi.SecurityScheme{
+				OpenIDConnect: &openapi.SecuritySchemeOpenIDConnect{
+					OpenIDConnectURL: "https://auth.example.com/.well-known/openid-configuration",
+				},
+			},
+		},
+		{
+			name:   "Mutual TLS Scheme",
+			scheme: "mtls",
+			opts: []option.SecurityOption{
+				option.SecurityMutualTLS(),
+			},
+			expected: &openapi.SecurityScheme{
+				MutualTLS: &openapi.SecuritySchemeMutualTLS{},
+			},
+		},
 		{
 			name:   "OAuth2 Scheme",
 			scheme: "oauth2",
@@ -713,6 +765,86 @@ func TestWithTags(t *testing.T) {
 	assert.Equal(t, "Order management", config.Tags[1].Description)
 }
 
+func TestWithFragment(t *testing.T) {
+	config := &openapi.Config{}
+	option.WithFragment([]byte("paths: {}"))(config)
+	option.WithFragment([]byte(`{"tags": []}`))(config)
+
+	require.Len(t, config.Fragments, 2)
+	assert.Equal(t, []byte("paths: {}"), config.Fragments[0].Data)
+	assert.Equal(t, []byte(`{"tags": []}`), config.Fragments[1].Data)
+}
+
+func TestWithFragmentFS(t *testing.T) {
+	fsys := fstest.MapFS{}
+
+	config := &openapi.Config{}
+	option.WithFragmentFS(fsys, "paths/*.yaml", "components/*.yaml")(config)
+
+	assert.Equal(t, []openapi.Fragment{
+		{FS: fsys, Pattern: "paths/*.yaml"},
+		{FS: fsys, Pattern: "components/*.yaml"},
+	}, config.Fragments)
+}
+
+func TestWithSpecTransformer(t *testing.T) {
+	var calls []string
+	config := &openapi.Config{}
+	option.WithSpecTransformer(func(any) error {
+		calls = append(calls, "any")
+		return nil
+	})(config)
+	option.WithSpecTransformer3(func(*openapi3.Spec) error {
+		calls = append(calls, "3.0")
+		return nil
+	})(config)
+	option.WithSpecTransformer31(func(*openapi31.Spec) error {
+		calls = append(calls, "3.1")
+		return errors.New("invalid")
+	})(config)
+	require.Len(t, config.SpecTransformers, 3)
+
+	for _, fn := range config.SpecTransformers {
+		require.NoError(t, fn(&openapi3.Spec{}))
+	}
+	assert.Equal(t, []string{"any", "3.0"}, calls)
+
+	calls = nil
+	require.NoError(t, config.SpecTransformers[1](&openapi31.Spec{}))
+	require.EqualError(t, config.SpecTransformers[2](&openapi31.Spec{}), "invalid")
+	assert.Equal(t, []string{"3.1"}, calls)
+}
+
+func TestWithComponents(t *testing.T) {
+	type ErrorBody struct {
+		Message string `json:"message"`
+	}
+	type PageParam struct {
+		Page int `query:"page"`
+	}
+
+	config := &openapi.Config{}
+	option.WithComponentResponse("NotFound", 404, ErrorBody{}, option.ContentDescription("Not found"))(config)
+	option.WithComponentResponse("NotFound", 404, "", option.ContentType("text/plain"))(config)
+	option.WithComponentParameter("Page", PageParam{})(config)
+	option.WithComponentRequestBody("Body", ErrorBody{})(config)
+	option.WithComponentHeader("RetryAfter", 0, "Seconds to wait")(config)
+
+	assert.Equal(t, openapi.Components{
+		Responses: map[string][]*openapi.ContentUnit{
+			"NotFound": {
+				{HTTPStatus: 404, Structure: ErrorBody{}, Description: "Not found"},
+				{HTTPStatus: 404, Structure: "", ContentType: "text/plain"},
+			},
+		},
+		Parameters:    map[string]any{"Page": PageParam{}},
+		RequestBodies: map[string][]*openapi.ContentUnit{"Body": {{Structure: ErrorBody{}}}},
+		Headers: map[string]openapi.Header{
+			"RetryAfter": {Name: "RetryAfter", Schema: 0, Description: "Seconds to wait"},
+		},
+	}, config.Components)
+}
+
 func TestWithReflectorConfig(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -13,16 +13,34 @@ type OperationConfig struct {
 	Summary     string
 	Deprecated  bool
 	Tags        []string
-	Security    []OperationSecurityConfig
+	Security    []OperationSecurityConfig // Empty but not nil for a public operation.
 
 	Requests  []*openapi.ContentUnit
 	Responses []*openapi.ContentUnit
+	Callbacks []CallbackConfig
+
+	GroupParams       []any  // Parameter structures of the groups, added unless the requests declare the same parameters.
+	OperationIDPrefix string // Prefix of the groups, added to the operation ID once it is set or generated.
+
+	ParameterRefs  []string            // Names of parameter components.
+	RequestBodyRef string              // Name of a request body component.
+	ResponseRefs   []ResponseRefConfig // References to response components.
+}
+
+// ResponseRefConfig references a response component.
+type ResponseRefConfig struct {
+	Name       string // Name of the response component.
+	HTTPStatus int    // Status the response is documented under; zero uses the status of the component.
 }
 
 // OperationSecurityConfig defines a security requirement for an operation.
+//
+// A requirement uses the scheme called Name, or all the schemes in All at once.
+// A requirement without any scheme makes the security of the operation optional.
 type OperationSecurityConfig struct {
 	Name   string
 	Scopes []string
+	All    []OperationSecurityConfig // Schemes that must all be satisfied together.
 }
 
 // OperationOption applies configuration to an OpenAPI operation.
@@ -97,6 +115,53 @@ func Security(securityName string, scopes ...string) OperationOption {
 	}
 }
 
+// SecurityRequirement returns a requirement of the named security scheme with the given scopes,
+// to be combined with others in SecurityAll.
+func SecurityRequirement(securityName string, scopes ...string) OperationSecurityConfig {
+	return OperationSecurityConfig{
+		Name:   securityName,
+		Scopes: scopes,
+	}
+}
+
+// SecurityAll adds a security requirement satisfied only when all the given schemes are.
+//
+// Each call to Security adds an alternative requirement, while SecurityAll combines schemes:
+//
+//	r.Post("/transfers",
+//	    option.SecurityAll(
+//	        option.SecurityRequirement("apiKey"),
+//	        option.SecurityRequirement("bearerAuth"),
+//	    ),
+//	)
+func SecurityAll(requirements ...OperationSecurityConfig) OperationOption {
+	return func(cfg *OperationConfig) {
+		cfg.Security = append(cfg.Security, OperationSecurityConfig{
+			All: requirements,
+		})
+	}
+}
+
+// SecurityOptional adds an empty security requirement, so the operation can also be called
+// without satisfying any of its other security requirements.
+func SecurityOptional() OperationOption {
+	return func(cfg *OperationConfig) {
+		cfg.Security = append(cfg.Security, OperationSecurityConfig{})
+	}
+}
+
+// NoSecurity removes the security requirements added so far, including those inherited
+// from the groups of the operation, and makes the operation public.
+//
+// Unless requirements are added after it, the operation is documented with an empty
+// security array, which also overrides the requirements declared at the top level of
+// the document. It is typically used for public endpoints inside a secured group.
+func NoSecurity() OperationOption {
+	return func(cfg *OperationConfig) {
+		cfg.Security = []OperationSecurityConfig{}
+	}
+}
+
 // Request adds a request body or parameter structure to the OpenAPI operation.
 func Request(structure any, options ...ContentOption) OperationOption {
 	return func(cfg *OperationConfig) {
@@ -110,6 +175,40 @@ func Request(structure any, options ...ContentOption) OperationOption {
 	}
 }
 
+// ParameterRef adds parameters defined with option.WithComponentParameter to the operation.
+func ParameterRef(names ...string) OperationOption {
+	return func(cfg *OperationConfig) {
+		cfg.ParameterRefs = append(cfg.ParameterRefs, names...)
+	}
+}
+
+// RequestBodyRef uses a request body defined with option.WithComponentRequestBody.
+//
+// The referenced body replaces any body reflected from Request structures, while
+// their parameters are kept.
+func RequestBodyRef(name string) OperationOption {
+	return func(cfg *OperationConfig) {
+		cfg.RequestBodyRef = name
+	}
+}
+
+// ResponseRef adds a response defined with option.WithComponentResponse to the operation.
+//
+// The response is documented under the status of the component, or under httpStatus if given.
+//
+// Example:
+//
+//	option.ResponseRef("NotFound")
+//	option.ResponseRef("Error", 500)
+func ResponseRef(name string, httpStatus ...int) OperationOption {
+	return func(cfg *OperationConfig) {
+		cfg.ResponseRefs = append(cfg.ResponseRefs, ResponseRefConfig{
+			Name:       name,
+			HTTPStatus: util.Optional(0, httpStatus...),
+		})
+	}
+}
+
 // Response adds a response for the OpenAPI operation.
 //
 // The HTTP status code defines which response is described.
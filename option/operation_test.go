@@ -120,6 +120,37 @@ func TestSecurity(t *testing.T) {
 		assert.Equal(t, "bearer", cfg.Security[0].Name)
 		assert.Equal(t, "oauth2", cfg.Security[1].Name)
 	})
+
+	t.Run("combined security", func(t *testing.T) {
+		cfg := &option.OperationConfig{}
+		option.SecurityAll(
+			option.SecurityRequirement("apiKey"),
+			option.SecurityRequirement("oauth2", "read"),
+		)(cfg)
+		assert.Equal(t, []option.OperationSecurityConfig{{
+			All: []option.OperationSecurityConfig{
+				{Name: "apiKey"},
+				{Name: "oauth2", Scopes: []string{"read"}},
+			},
+		}}, cfg.Security)
+	})
+
+	t.Run("optional security", func(t *testing.T) {
+		cfg := &option.OperationConfig{}
+		option.Security("bearer")(cfg)
+		option.SecurityOptional()(cfg)
+		assert.Equal(t, []option.OperationSecurityConfig{{Name: "bearer"}, {}}, cfg.Security)
+	})
+
+	t.Run("no security", func(t *testing.T) {
+		cfg := &option.OperationConfig{}
+		option.Security("bearer")(cfg)
+		option.NoSecurity()(cfg)
+		assert.NotNil(t, cfg.Security)
+		assert.Empty(t, cfg.Security)
+		option.Security("apiKey")(cfg)
+		assert.Equal(t, []option.OperationSecurityConfig{{Name: "apiKey"}}, cfg.Security)
+	})
 }
 
 func TestRequest(t *testing.T) {
@@ -186,6 +217,21 @@ func TestResponse(t *testing.T) {
 	})
 }
 
+func TestComponentRefs(t *testing.T) {
+	cfg := &option.OperationConfig{}
+	option.ParameterRef("Page", "Limit")(cfg)
+	option.RequestBodyRef("CreatePet")(cfg)
+	option.ResponseRef("NotFound")(cfg)
+	option.ResponseRef("Error", 500)(cfg)
+
+	assert.Equal(t, []string{"Page", "Limit"}, cfg.ParameterRefs)
+	assert.Equal(t, "CreatePet", cfg.RequestBodyRef)
+	assert.Equal(t, []option.ResponseRefConfig{
+		{Name: "NotFound"},
+		{Name: "Error", HTTPStatus: 500},
+	}, cfg.ResponseRefs)
+}
+
 func TestOperationConfig(t *testing.T) {
 	t.Run("default values", func(t *testing.T) {
 		cfg := &option.OperationConfig{}
@@ -0,0 +1,58 @@
+package option
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// OperationIDFunc returns the operation ID of an operation that does not set one.
+//
+// The method is upper-case and the path is the OpenAPI path template, such as "/pets/{petId}".
+type OperationIDFunc func(method, path string, cfg *OperationConfig) string
+
+// OperationIDCamelCase generates camel case operation IDs from the method and the path,
+// such as "getPetsByPetId" for GET /pets/{petId}.
+func OperationIDCamelCase(method, path string, _ *OperationConfig) string {
+	var sb strings.Builder
+	sb.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		name, isParam := pathSegmentName(segment)
+		if isParam {
+			sb.WriteString("By")
+		}
+		for _, word := range splitWords(name) {
+			first, size := utf8.DecodeRuneInString(word)
+			sb.WriteRune(unicode.ToUpper(first))
+			sb.WriteString(word[size:])
+		}
+	}
+	return sb.String()
+}
+
+// OperationIDDotted generates dotted operation IDs from the path and the method,
+// such as "pets.get" for GET /pets and "pets.petId.get" for GET /pets/{petId}.
+func OperationIDDotted(method, path string, _ *OperationConfig) string {
+	var parts []string
+	for _, segment := range strings.Split(path, "/") {
+		if name, _ := pathSegmentName(segment); name != "" {
+			parts = append(parts, name)
+		}
+	}
+	return strings.Join(append(parts, strings.ToLower(method)), ".")
+}
+
+// pathSegmentName returns the name of a path segment, without the braces of a parameter.
+func pathSegmentName(segment string) (string, bool) {
+	if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+		return segment[1 : len(segment)-1], true
+	}
+	return segment, false
+}
+
+// splitWords splits a path segment into words on any character that is not a letter or a digit.
+func splitWords(segment string) []string {
+	return strings.FieldsFunc(segment, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,58 @@
+package option_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec/openapi"
+	"github.com/oaswrap/spec/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationIDFuncs(t *testing.T) {
+	tests := []struct {
+		method    string
+		path      string
+		camelCase string
+		dotted    string
+	}{
+		{"GET", "/", "get", "get"},
+		{"GET", "/pets", "getPets", "pets.get"},
+		{"POST", "/pets", "postPets", "pets.post"},
+		{"GET", "/pets/{petId}", "getPetsByPetId", "pets.petId.get"},
+		{
+			"DELETE", "/pets/{petId}/photos/{photo_id}",
+			"deletePetsByPetIdPhotosByPhotoId", "pets.petId.photos.photo_id.delete",
+		},
+		{"PUT", "/pet-types/v2", "putPetTypesV2", "pet-types.v2.put"},
+		{"GET", "/école/élèves", "getÉcoleÉlèves", "école.élèves.get"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.camelCase, option.OperationIDCamelCase(tt.method, tt.path, nil))
+			assert.Equal(t, tt.dotted, option.OperationIDDotted(tt.method, tt.path, nil))
+		})
+	}
+}
+
+func TestWithOperationIDFunc(t *testing.T) {
+	t.Run("passes the operation config", func(t *testing.T) {
+		config := &openapi.Config{}
+		option.WithOperationIDFunc(func(method, path string, cfg *option.OperationConfig) string {
+			return cfg.Tags[0] + "." + method + path
+		})(config)
+
+		require.NotNil(t, config.OperationIDFunc)
+		cfg := &option.OperationConfig{Tags: []string{"pets"}}
+		assert.Equal(t, "pets.GET/pets", config.OperationIDFunc("GET", "/pets", cfg))
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		config := &openapi.Config{}
+		option.WithOperationIDFunc(option.OperationIDDotted)(config)
+		option.WithOperationIDFunc(nil)(config)
+
+		assert.Nil(t, config.OperationIDFunc)
+	})
+}
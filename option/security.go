@@ -4,10 +4,13 @@ import "github.com/oaswrap/spec/openapi"
 
 // securityConfig holds configuration for defining a security scheme.
 type securityConfig struct {
-	Description *string
-	APIKey      *openapi.SecuritySchemeAPIKey
-	HTTPBearer  *openapi.SecuritySchemeHTTPBearer
-	Oauth2      *openapi.SecuritySchemeOAuth2
+	Description   *string
+	APIKey        *openapi.SecuritySchemeAPIKey
+	HTTP          *openapi.SecuritySchemeHTTP
+	HTTPBearer    *openapi.SecuritySchemeHTTPBearer
+	Oauth2        *openapi.SecuritySchemeOAuth2
+	OpenIDConnect *openapi.SecuritySchemeOpenIDConnect
+	MutualTLS     *openapi.SecuritySchemeMutualTLS
 }
 
 // SecurityOption applies configuration to a securityConfig.
@@ -42,6 +45,23 @@ func SecurityAPIKey(name string, in openapi.SecuritySchemeAPIKeyIn) SecurityOpti
 	}
 }
 
+// SecurityHTTP defines an HTTP security scheme with the given scheme name, such as
+// "basic" or "digest".
+//
+// Use SecurityHTTPBearer for Bearer authentication.
+func SecurityHTTP(scheme string) SecurityOption {
+	return func(cfg *securityConfig) {
+		cfg.HTTP = &openapi.SecuritySchemeHTTP{
+			Scheme: scheme,
+		}
+	}
+}
+
+// SecurityHTTPBasic defines an HTTP Basic security scheme.
+func SecurityHTTPBasic() SecurityOption {
+	return SecurityHTTP("basic")
+}
+
 // SecurityHTTPBearer defines an HTTP Bearer security scheme.
 //
 // Optionally, you can provide a bearer format.
@@ -65,3 +85,23 @@ func SecurityOAuth2(flows openapi.OAuthFlows) SecurityOption {
 		}
 	}
 }
+
+// SecurityOpenIDConnect defines an OpenID Connect security scheme.
+//
+// The URL points to the OpenID Connect discovery document.
+func SecurityOpenIDConnect(url string) SecurityOption {
+	return func(cfg *securityConfig) {
+		cfg.OpenIDConnect = &openapi.SecuritySchemeOpenIDConnect{
+			OpenIDConnectURL: url,
+		}
+	}
+}
+
+// SecurityMutualTLS defines a mutual TLS security scheme.
+//
+// Mutual TLS is only available in OpenAPI 3.1.
+func SecurityMutualTLS() SecurityOption {
+	return func(cfg *securityConfig) {
+		cfg.MutualTLS = &openapi.SecuritySchemeMutualTLS{}
+	}
+}
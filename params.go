@@ -0,0 +1,105 @@
+package spec
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	specopenapi "github.com/oaswrap/spec/openapi"
+)
+
+// structParams returns the fields of a request structure declaring parameters, such as
+// "GetPetRequest.ID", keyed by location and name, such as "path petId". Embedded structures
+// are included, and fields of anonymous structures are named without their type.
+func structParams(structure any) map[string]string {
+	params := map[string]string{}
+	if structure == nil {
+		return params
+	}
+	collectParams(reflect.TypeOf(structure), params, map[reflect.Type]bool{})
+	return params
+}
+
+func collectParams(t reflect.Type, params map[string]string, visited map[reflect.Type]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || visited[t] {
+		return
+	}
+	visited[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			collectParams(field.Type, params, visited)
+			continue
+		}
+		fieldName := field.Name
+		if t.Name() != "" {
+			fieldName = t.Name() + "." + field.Name
+		}
+		for _, in := range []string{"path", "query", "header", "cookie"} {
+			if name, ok := field.Tag.Lookup(in); ok && name != "" && name != "-" {
+				params[in+" "+name] = fieldName
+			}
+		}
+	}
+}
+
+// checkPathParams reports the parameters of the path template that no path field of the
+// requests declares, and the path fields of the requests that the template does not contain.
+func checkPathParams(method, path string, requests []*specopenapi.ContentUnit) []error {
+	path = reTemplateParam.ReplaceAllString(path, "{$1}")
+	fields := map[string]string{}
+	for _, req := range requests {
+		for param, field := range structParams(req.Structure) {
+			if name, ok := strings.CutPrefix(param, "path "); ok {
+				fields[name] = field
+			}
+		}
+	}
+
+	var errs []error
+	inPath := map[string]bool{}
+	for _, match := range reTemplateParam.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		inPath[name] = true
+		if _, ok := fields[name]; !ok {
+			errs = append(errs, fmt.Errorf("%s %s: path parameter %q is not declared by a `path` field of the request",
+				method, path, name))
+		}
+	}
+	for _, name := range sortedKeys(fields) {
+		if !inPath[name] {
+			errs = append(errs, fmt.Errorf("%s %s: field %s declares path parameter %q, which is not in the path",
+				method, path, fields[name], name))
+		}
+	}
+	return errs
+}
+
+// withGroupParams returns the requests preceded by the parameter structures of the groups,
+// leaving out those whose parameters are all declared by the requests already.
+func withGroupParams(groupParams []any, requests []*specopenapi.ContentUnit) []*specopenapi.ContentUnit {
+	if len(groupParams) == 0 {
+		return requests
+	}
+	declared := map[string]bool{}
+	for _, req := range requests {
+		for param := range structParams(req.Structure) {
+			declared[param] = true
+		}
+	}
+	units := make([]*specopenapi.ContentUnit, 0, len(groupParams)+len(requests))
+	for _, structure := range groupParams {
+		params := structParams(structure)
+		redeclared := len(params) > 0
+		for param := range params {
+			redeclared = redeclared && declared[param]
+		}
+		if !redeclared {
+			units = append(units, &specopenapi.ContentUnit{Structure: structure})
+		}
+	}
+	return append(units, requests...)
+}
@@ -0,0 +1,232 @@
+// Package diff detects breaking changes between two OpenAPI documents.
+//
+// It compares a baseline document, such as a committed openapi.yaml, with a revision,
+// such as the current output of Generator.MarshalJSON, and classifies every change as
+// breaking or non-breaking for existing clients. Both OpenAPI 3.0 and 3.1 documents are
+// supported, and the two may be mixed.
+//
+// Example:
+//
+//	baseline, _ := os.ReadFile("testdata/openapi.yaml")
+//	current, _ := r.MarshalJSON()
+//
+//	report, err := diff.Compare(baseline, current)
+//	require.NoError(t, err)
+//	require.NoError(t, report.Err())
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/oaswrap/spec/internal/document"
+)
+
+// ErrBreakingChanges is wrapped by Report.Err when breaking changes are found.
+var ErrBreakingChanges = errors.New("breaking changes detected")
+
+// Kind identifies the type of a change.
+type Kind string
+
+// Change kinds reported by Compare.
+const (
+	OperationRemoved    Kind = "operation-removed"
+	OperationAdded      Kind = "operation-added"
+	OperationDeprecated Kind = "operation-deprecated"
+
+	ParameterRemoved  Kind = "parameter-removed"
+	ParameterAdded    Kind = "parameter-added"
+	ParameterRequired Kind = "parameter-required"
+	ParameterOptional Kind = "parameter-optional"
+
+	RequestBodyRemoved  Kind = "request-body-removed"
+	RequestBodyAdded    Kind = "request-body-added"
+	RequestBodyRequired Kind = "request-body-required"
+	RequestBodyOptional Kind = "request-body-optional"
+
+	ResponseRemoved       Kind = "response-removed"
+	ResponseAdded         Kind = "response-added"
+	ResponseHeaderRemoved Kind = "response-header-removed"
+
+	MediaTypeRemoved Kind = "media-type-removed"
+	MediaTypeAdded   Kind = "media-type-added"
+
+	TypeChanged        Kind = "type-changed"
+	EnumChanged        Kind = "enum-changed"
+	FormatChanged      Kind = "format-changed"
+	ConstraintChanged  Kind = "constraint-changed"
+	PropertyRemoved    Kind = "property-removed"
+	PropertyAdded      Kind = "property-added"
+	PropertyRequired   Kind = "property-required"
+	PropertyOptional   Kind = "property-optional"
+	CompositionChanged Kind = "composition-changed"
+
+	SecurityChanged       Kind = "security-changed"
+	SecuritySchemeRemoved Kind = "security-scheme-removed"
+	SecuritySchemeAdded   Kind = "security-scheme-added"
+	SecuritySchemeChanged Kind = "security-scheme-changed"
+)
+
+// Change describes a single difference between the base and the revision.
+type Change struct {
+	Kind     Kind   // Type of the change.
+	Breaking bool   // Whether existing clients may break.
+	Method   string // Upper-case HTTP method, empty for document-level changes.
+	Path     string // Path template of the operation in the revision, or in the base if removed.
+	Pointer  string // JSON pointer to the changed element, in the revision or in the base if removed.
+	Message  string // Human-readable description.
+}
+
+// String returns a one-line representation of the change.
+func (c Change) String() string {
+	var sb strings.Builder
+	if c.Breaking {
+		sb.WriteString("[breaking] ")
+	} else {
+		sb.WriteString("[non-breaking] ")
+	}
+	if c.Method != "" {
+		sb.WriteString(c.Method + " " + c.Path + ": ")
+	}
+	sb.WriteString(c.Message)
+	return sb.String()
+}
+
+// Report lists the changes found by Compare, in a deterministic order.
+type Report struct {
+	Changes []Change
+}
+
+// Breaking returns the breaking changes.
+func (r *Report) Breaking() []Change {
+	var out []Change
+	for _, c := range r.Changes {
+		if c.Breaking {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// HasBreaking reports whether any change is breaking.
+func (r *Report) HasBreaking() bool {
+	return len(r.Breaking()) > 0
+}
+
+// Err returns an error wrapping ErrBreakingChanges that lists every breaking change,
+// or nil if there are none.
+func (r *Report) Err() error {
+	breaking := r.Breaking()
+	if len(breaking) == 0 {
+		return nil
+	}
+	var sb strings.Builder
+	for _, c := range breaking {
+		sb.WriteString("\n- ")
+		sb.WriteString(c.String())
+	}
+	return fmt.Errorf("%w:%s", ErrBreakingChanges, sb.String())
+}
+
+// String returns all changes, one per line.
+func (r *Report) String() string {
+	lines := make([]string, 0, len(r.Changes))
+	for _, c := range r.Changes {
+		lines = append(lines, c.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Compare returns the changes from base to revision.
+//
+// Both documents may be JSON or YAML, in OpenAPI 3.0 or 3.1.
+func Compare(base, revision []byte) (*Report, error) {
+	baseDoc, err := document.Parse(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base document: %w", err)
+	}
+	revDoc, err := document.Parse(revision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse revision document: %w", err)
+	}
+
+	c := &comparer{
+		base:   baseDoc,
+		rev:    revDoc,
+		report: &Report{},
+	}
+	c.operations()
+	c.securitySchemes()
+	return c.report, nil
+}
+
+type comparer struct {
+	base   *document.Document
+	rev    *document.Document
+	report *Report
+
+	// Current operation, used to annotate changes.
+	method string
+	path   string
+}
+
+func (c *comparer) add(kind Kind, breaking bool, pointer, format string, args ...any) {
+	c.report.Changes = append(c.report.Changes, Change{
+		Kind:     kind,
+		Breaking: breaking,
+		Method:   c.method,
+		Path:     c.path,
+		Pointer:  pointer,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *comparer) securitySchemes() {
+	c.method, c.path = "", ""
+	base := document.Map(document.Map(c.base.Raw(), "components"), "securitySchemes")
+	rev := document.Map(document.Map(c.rev.Raw(), "components"), "securitySchemes")
+	basePtr := document.Pointer("components", "securitySchemes")
+
+	for _, name := range document.SortedKeys(base) {
+		baseScheme, _ := c.base.Resolve(document.Map(base, name), document.Append(basePtr, name))
+		if _, ok := rev[name]; !ok {
+			c.add(SecuritySchemeRemoved, true, document.Append(basePtr, name),
+				"security scheme %q removed", name)
+			continue
+		}
+		revScheme, _ := c.rev.Resolve(document.Map(rev, name), document.Append(basePtr, name))
+		c.securityScheme(name, document.Append(basePtr, name), baseScheme, revScheme)
+	}
+	for _, name := range document.SortedKeys(rev) {
+		if _, ok := base[name]; !ok {
+			c.add(SecuritySchemeAdded, false, document.Append(basePtr, name),
+				"security scheme %q added", name)
+		}
+	}
+}
+
+func (c *comparer) securityScheme(name, pointer string, base, rev map[string]any) {
+	for _, key := range []string{"type", "scheme", "in", "name", "openIdConnectUrl"} {
+		if b, r := document.String(base, key), document.String(rev, key); b != r {
+			c.add(SecuritySchemeChanged, true, document.Append(pointer, key),
+				"security scheme %q %s changed from %q to %q", name, key, b, r)
+		}
+	}
+	baseFlows, revFlows := document.Map(base, "flows"), document.Map(rev, "flows")
+	for _, flow := range document.SortedKeys(baseFlows) {
+		revFlow := document.Map(revFlows, flow)
+		if revFlow == nil {
+			c.add(SecuritySchemeChanged, true, document.Append(pointer, "flows", flow),
+				"security scheme %q flow %q removed", name, flow)
+			continue
+		}
+		revScopes := document.Map(revFlow, "scopes")
+		for _, scope := range document.SortedKeys(document.Map(document.Map(baseFlows, flow), "scopes")) {
+			if _, ok := revScopes[scope]; !ok {
+				c.add(SecuritySchemeChanged, true, document.Append(pointer, "flows", flow, "scopes"),
+					"security scheme %q flow %q scope %q removed", name, flow, scope)
+			}
+		}
+	}
+}
@@ -0,0 +1,430 @@
+package diff_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/option"
+	"github.com/oaswrap/spec/pkg/diff"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Pet struct {
+	ID     int    `json:"id"     required:"true"`
+	Name   string `json:"name"   required:"true"`
+	Status string `json:"status" enum:"available,pending,sold"`
+}
+
+type PetV2 struct {
+	ID     string `json:"id"     required:"true"`
+	Status string `json:"status" enum:"available,sold"`
+}
+
+type CreatePet struct {
+	Name   string `json:"name"   required:"true"`
+	Status string `json:"status" enum:"available,pending,sold"`
+}
+
+type CreatePetV2 struct {
+	Name   string `json:"name"   required:"true"`
+	Status string `json:"status" enum:"available,sold"`
+}
+
+type GetPet struct {
+	ID int `path:"petId"`
+}
+
+type GetPetRenamed struct {
+	ID int `path:"id"`
+}
+
+type ListPets struct {
+	Limit int `query:"limit"`
+}
+
+type ListPetsV2 struct {
+	Limit  int    `query:"limit"`
+	Owner  string `query:"owner"  required:"true"`
+	Cursor string `query:"cursor"`
+}
+
+type routes struct {
+	itemPath  string
+	pet       any
+	createPet any
+	getPet    any
+	listPets  any
+	noDelete  bool
+	noAuth    bool
+	extraResp bool
+}
+
+func newSpec(t *testing.T, version string, rt routes) []byte {
+	t.Helper()
+	opts := []option.OpenAPIOption{
+		option.WithOpenAPIVersion(version),
+		option.WithTitle("Pet API"),
+		option.WithVersion("1.0.0"),
+	}
+	if !rt.noAuth {
+		opts = append(opts, option.WithSecurity("bearerAuth", option.SecurityHTTPBearer("JWT")))
+	}
+	r := spec.NewRouter(opts...)
+	listOpts := []option.OperationOption{
+		option.Request(rt.listPets),
+		option.Response(200, []Pet{}),
+	}
+	if rt.extraResp {
+		listOpts = append(listOpts, option.Response(429, nil))
+	}
+	r.Get("/pets", listOpts...)
+	r.Post("/pets",
+		option.Request(rt.createPet),
+		option.Response(201, rt.pet),
+	)
+	r.Get(rt.itemPath,
+		option.Request(rt.getPet),
+		option.Response(200, rt.pet),
+	)
+	if !rt.noDelete {
+		deleteOpts := []option.OperationOption{
+			option.Request(rt.getPet),
+			option.Response(204, nil),
+		}
+		if !rt.noAuth {
+			deleteOpts = append(deleteOpts, option.Security("bearerAuth"))
+		}
+		r.Delete(rt.itemPath, deleteOpts...)
+	}
+	data, err := r.MarshalJSON()
+	require.NoError(t, err)
+	return data
+}
+
+func baseRoutes() routes {
+	return routes{
+		itemPath:  "/pets/{petId}",
+		pet:       new(Pet),
+		createPet: new(CreatePet),
+		getPet:    new(GetPet),
+		listPets:  new(ListPets),
+	}
+}
+
+type change struct {
+	Kind     diff.Kind
+	Breaking bool
+	Op       string
+	Message  string
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(rt *routes)
+		want   []change
+	}{
+		{
+			name:   "no changes",
+			modify: func(*routes) {},
+		},
+		{
+			name: "path parameter renamed",
+			modify: func(rt *routes) {
+				rt.itemPath = "/pets/{id}"
+				rt.getPet = new(GetPetRenamed)
+			},
+		},
+		{
+			name: "operation removed",
+			modify: func(rt *routes) {
+				rt.noDelete = true
+			},
+			want: []change{
+				{diff.OperationRemoved, true, "DELETE /pets/{petId}", "operation removed"},
+			},
+		},
+		{
+			name: "parameters added",
+			modify: func(rt *routes) {
+				rt.listPets = new(ListPetsV2)
+			},
+			want: []change{
+				{diff.ParameterAdded, true, "GET /pets", `required query parameter "owner" added`},
+				{diff.ParameterAdded, false, "GET /pets", `optional query parameter "cursor" added`},
+			},
+		},
+		{
+			name: "request enum narrowed",
+			modify: func(rt *routes) {
+				rt.createPet = new(CreatePetV2)
+			},
+			want: []change{
+				{
+					diff.EnumChanged, true, "POST /pets",
+					`request body application/json property "status" enum values removed: "pending"`,
+				},
+			},
+		},
+		{
+			name: "response schema changed",
+			modify: func(rt *routes) {
+				rt.pet = new(PetV2)
+			},
+			want: []change{
+				{
+					diff.TypeChanged, true, "POST /pets",
+					`response 201 application/json property "id" type changed from integer to string`,
+				},
+				{
+					diff.PropertyOptional, true, "POST /pets",
+					`response 201 application/json property "name" became optional`,
+				},
+				{
+					diff.PropertyRemoved, true, "POST /pets",
+					`response 201 application/json property "name" removed`,
+				},
+				{
+					diff.EnumChanged, false, "POST /pets",
+					`response 201 application/json property "status" enum values removed: "pending"`,
+				},
+				{
+					diff.TypeChanged, true, "GET /pets/{petId}",
+					`response 200 application/json property "id" type changed from integer to string`,
+				},
+				{
+					diff.PropertyOptional, true, "GET /pets/{petId}",
+					`response 200 application/json property "name" became optional`,
+				},
+				{
+					diff.PropertyRemoved, true, "GET /pets/{petId}",
+					`response 200 application/json property "name" removed`,
+				},
+				{
+					diff.EnumChanged, false, "GET /pets/{petId}",
+					`response 200 application/json property "status" enum values removed: "pending"`,
+				},
+			},
+		},
+		{
+			name: "security scheme removed",
+			modify: func(rt *routes) {
+				rt.noAuth = true
+			},
+			want: []change{
+				{diff.SecurityChanged, false, "DELETE /pets/{petId}", "security requirements removed"},
+				{diff.SecuritySchemeRemoved, true, "", `security scheme "bearerAuth" removed`},
+			},
+		},
+		{
+			name: "response added",
+			modify: func(rt *routes) {
+				rt.extraResp = true
+			},
+			want: []change{
+				{diff.ResponseAdded, false, "GET /pets", "response 429 added"},
+			},
+		},
+	}
+
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		for _, tt := range tests {
+			t.Run(version+"/"+tt.name, func(t *testing.T) {
+				rev := baseRoutes()
+				tt.modify(&rev)
+
+				report, err := diff.Compare(newSpec(t, version, baseRoutes()), newSpec(t, version, rev))
+				require.NoError(t, err)
+
+				got := make([]change, 0, len(report.Changes))
+				for _, c := range report.Changes {
+					op := ""
+					if c.Method != "" {
+						op = c.Method + " " + c.Path
+					}
+					got = append(got, change{c.Kind, c.Breaking, op, c.Message})
+				}
+				assert.ElementsMatch(t, tt.want, got)
+
+				hasBreaking := false
+				for _, c := range tt.want {
+					hasBreaking = hasBreaking || c.Breaking
+				}
+				assert.Equal(t, hasBreaking, report.HasBreaking())
+				if hasBreaking {
+					require.ErrorIs(t, report.Err(), diff.ErrBreakingChanges)
+				} else {
+					require.NoError(t, report.Err())
+				}
+			})
+		}
+	}
+}
+
+func TestCompare_AcrossVersions(t *testing.T) {
+	report, err := diff.Compare(newSpec(t, "3.0.3", baseRoutes()), newSpec(t, "3.1.0", baseRoutes()))
+	require.NoError(t, err)
+	assert.Empty(t, report.Changes)
+}
+
+func TestCompare_Schemas(t *testing.T) {
+	const base = `
+openapi: 3.0.3
+info: {title: API, version: "1"}
+paths:
+  /items:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                count: {type: integer, maximum: 10}
+                label: {type: string, nullable: true}
+                price: {type: integer}
+      responses:
+        "200":
+          description: OK
+          headers:
+            X-Rate-Limit: {schema: {type: integer}}
+          content:
+            application/json:
+              schema:
+                oneOf:
+                - {type: string}
+                - {type: integer}
+`
+	const revision = `
+openapi: 3.1.0
+info: {title: API, version: "1"}
+paths:
+  /items:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                count: {type: integer, maximum: 5}
+                label: {type: string}
+                price: {type: number}
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                oneOf:
+                - {type: string}
+                - {type: integer}
+                - {type: boolean}
+`
+	report, err := diff.Compare([]byte(base), []byte(revision))
+	require.NoError(t, err)
+
+	messages := map[string]bool{}
+	for _, c := range report.Changes {
+		messages[c.String()] = true
+	}
+	assert.Equal(t, map[string]bool{
+		"[breaking] POST /items: request body became required":                                                true,
+		`[breaking] POST /items: request body application/json property "count" maximum changed from 10 to 5`: true,
+		`[breaking] POST /items: request body application/json property "label" type changed ` +
+			`from null|string to string`: true,
+		`[non-breaking] POST /items: request body application/json property "price" type changed ` +
+			`from integer to number`: true,
+		`[breaking] POST /items: response 200 header "X-Rate-Limit" removed`:                      true,
+		"[breaking] POST /items: response 200 application/json oneOf changed from 2 to 3 schemas": true,
+	}, messages)
+	assert.Len(t, report.Breaking(), 5)
+}
+
+func TestCompare_ExclusiveBounds(t *testing.T) {
+	document := func(version, schema string) []byte {
+		return []byte(`
+openapi: ` + version + `
+info: {title: API, version: "1"}
+paths:
+  /items:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema: {type: object, properties: {count: ` + schema + `}}
+      responses:
+        "204": {description: No Content}
+`)
+	}
+	tests := []struct {
+		name     string
+		base     []byte
+		revision []byte
+		changes  []string
+	}{
+		{
+			name:     "maximum becomes exclusive",
+			base:     document("3.0.3", "{type: integer, maximum: 10}"),
+			revision: document("3.0.3", "{type: integer, maximum: 10, exclusiveMaximum: true}"),
+			changes: []string{
+				`[breaking] POST /items: request body application/json property "count" ` +
+					`maximum changed from 10 to 10 (exclusive)`,
+			},
+		},
+		{
+			name:     "minimum is no longer exclusive",
+			base:     document("3.0.3", "{type: integer, minimum: 1, exclusiveMinimum: true}"),
+			revision: document("3.0.3", "{type: integer, minimum: 1, exclusiveMinimum: false}"),
+			changes: []string{
+				`[non-breaking] POST /items: request body application/json property "count" ` +
+					`minimum changed from 1 (exclusive) to 1`,
+			},
+		},
+		{
+			name:     "same bound across versions",
+			base:     document("3.0.3", "{type: integer, maximum: 10, exclusiveMaximum: true}"),
+			revision: document("3.1.0", "{type: integer, exclusiveMaximum: 10}"),
+		},
+		{
+			name:     "exclusive bound tightened across versions",
+			base:     document("3.0.3", "{type: integer, minimum: 1, exclusiveMinimum: true}"),
+			revision: document("3.1.0", "{type: integer, exclusiveMinimum: 2}"),
+			changes: []string{
+				`[breaking] POST /items: request body application/json property "count" ` +
+					`minimum changed from 1 (exclusive) to 2 (exclusive)`,
+			},
+		},
+		{
+			name:     "tightest of both bounds",
+			base:     document("3.1.0", "{type: integer, maximum: 10}"),
+			revision: document("3.1.0", "{type: integer, maximum: 10, exclusiveMaximum: 8}"),
+			changes: []string{
+				`[breaking] POST /items: request body application/json property "count" ` +
+					`maximum changed from 10 to 8 (exclusive)`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := diff.Compare(tt.base, tt.revision)
+			require.NoError(t, err)
+
+			var changes []string
+			for _, c := range report.Changes {
+				changes = append(changes, c.String())
+			}
+			assert.ElementsMatch(t, tt.changes, changes)
+		})
+	}
+}
+
+func TestCompare_InvalidDocument(t *testing.T) {
+	_, err := diff.Compare([]byte(`swagger: "2.0"`), []byte(`openapi: 3.0.3`))
+	require.ErrorContains(t, err, "failed to parse base document")
+
+	_, err = diff.Compare([]byte(`openapi: 3.0.3`), []byte(`{`))
+	require.ErrorContains(t, err, "failed to parse revision document")
+}
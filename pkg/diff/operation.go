@@ -0,0 +1,282 @@
+package diff
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec/internal/document"
+)
+
+// operationKey identifies an operation independently of its path parameter names,
+// so "/pets/{id}" and "/pets/{petId}" are the same operation.
+func operationKey(op document.Operation) string {
+	segments := strings.Split(op.Path, "/")
+	for i, seg := range segments {
+		if strings.Contains(seg, "{") {
+			segments[i] = "{}"
+		}
+	}
+	return op.Method + " " + strings.Join(segments, "/")
+}
+
+func (c *comparer) operations() {
+	revOps := map[string]document.Operation{}
+	for _, op := range c.rev.Operations() {
+		revOps[operationKey(op)] = op
+	}
+	seen := map[string]bool{}
+	for _, baseOp := range c.base.Operations() {
+		key := operationKey(baseOp)
+		seen[key] = true
+		revOp, ok := revOps[key]
+		if !ok {
+			c.method, c.path = baseOp.Method, baseOp.Path
+			c.add(OperationRemoved, true, baseOp.Pointer, "operation removed")
+			continue
+		}
+		c.method, c.path = revOp.Method, revOp.Path
+		c.operation(baseOp, revOp)
+	}
+	for _, revOp := range c.rev.Operations() {
+		if !seen[operationKey(revOp)] {
+			c.method, c.path = revOp.Method, revOp.Path
+			c.add(OperationAdded, false, revOp.Pointer, "operation added")
+		}
+	}
+}
+
+func (c *comparer) operation(base, rev document.Operation) {
+	if !document.Bool(base.Node, "deprecated") && document.Bool(rev.Node, "deprecated") {
+		c.add(OperationDeprecated, false, document.Append(rev.Pointer, "deprecated"), "operation deprecated")
+	}
+	c.parameters(base, rev)
+	c.requestBody(base, rev)
+	c.responses(base, rev)
+	c.security(base, rev)
+}
+
+// parameterKey identifies a parameter. Path parameters are matched by position so that
+// renaming a template variable is not reported as a removal.
+func parameterKey(op document.Operation, p document.Parameter) string {
+	switch p.In {
+	case "path":
+		for i, name := range document.PathParams(op.Path) {
+			if name == p.Name {
+				return "path:" + strconv.Itoa(i)
+			}
+		}
+	case "header":
+		return "header:" + strings.ToLower(p.Name)
+	}
+	return p.In + ":" + p.Name
+}
+
+func (c *comparer) parameters(base, rev document.Operation) {
+	revParams := map[string]document.Parameter{}
+	for _, p := range rev.Parameters {
+		revParams[parameterKey(rev, p)] = p
+	}
+	seen := map[string]bool{}
+	for _, bp := range base.Parameters {
+		key := parameterKey(base, bp)
+		seen[key] = true
+		rp, ok := revParams[key]
+		if !ok {
+			c.add(ParameterRemoved, false, bp.Pointer, "%s parameter %q removed", bp.In, bp.Name)
+			continue
+		}
+		switch {
+		case !bp.Required && rp.Required:
+			c.add(ParameterRequired, true, rp.Pointer, "%s parameter %q became required", rp.In, rp.Name)
+		case bp.Required && !rp.Required:
+			c.add(ParameterOptional, false, rp.Pointer, "%s parameter %q became optional", rp.In, rp.Name)
+		}
+		baseSchema, basePtr := bp.Schema()
+		revSchema, revPtr := rp.Schema()
+		c.schema(request, rp.In+" parameter "+strconv.Quote(rp.Name), basePtr, revPtr, baseSchema, revSchema)
+	}
+	for _, rp := range rev.Parameters {
+		if seen[parameterKey(rev, rp)] {
+			continue
+		}
+		if rp.Required {
+			c.add(ParameterAdded, true, rp.Pointer, "required %s parameter %q added", rp.In, rp.Name)
+		} else {
+			c.add(ParameterAdded, false, rp.Pointer, "optional %s parameter %q added", rp.In, rp.Name)
+		}
+	}
+}
+
+func (c *comparer) requestBody(base, rev document.Operation) {
+	baseBody, basePtr := c.base.Resolve(document.Map(base.Node, "requestBody"),
+		document.Append(base.Pointer, "requestBody"))
+	revBody, revPtr := c.rev.Resolve(document.Map(rev.Node, "requestBody"),
+		document.Append(rev.Pointer, "requestBody"))
+
+	switch {
+	case baseBody == nil && revBody == nil:
+		return
+	case baseBody == nil:
+		required := document.Bool(revBody, "required")
+		c.add(RequestBodyAdded, required, revPtr, "request body added")
+		return
+	case revBody == nil:
+		c.add(RequestBodyRemoved, true, basePtr, "request body removed")
+		return
+	}
+
+	baseRequired, revRequired := document.Bool(baseBody, "required"), document.Bool(revBody, "required")
+	switch {
+	case !baseRequired && revRequired:
+		c.add(RequestBodyRequired, true, revPtr, "request body became required")
+	case baseRequired && !revRequired:
+		c.add(RequestBodyOptional, false, revPtr, "request body became optional")
+	}
+	c.content(request, "request body", basePtr, revPtr, baseBody, revBody)
+}
+
+func (c *comparer) responses(base, rev document.Operation) {
+	baseResponses := document.Map(base.Node, "responses")
+	revResponses := document.Map(rev.Node, "responses")
+
+	for _, status := range document.SortedKeys(baseResponses) {
+		basePtr := document.Append(base.Pointer, "responses", status)
+		if _, ok := revResponses[status]; !ok {
+			// Clients rely on documented success responses; error responses are advisory.
+			breaking := strings.HasPrefix(status, "2")
+			c.add(ResponseRemoved, breaking, basePtr, "response %s removed", status)
+			continue
+		}
+		baseResp, basePtr := c.base.Resolve(document.Map(baseResponses, status), basePtr)
+		revResp, revPtr := c.rev.Resolve(document.Map(revResponses, status),
+			document.Append(rev.Pointer, "responses", status))
+
+		revHeaders := document.Map(revResp, "headers")
+		for _, name := range document.SortedKeys(document.Map(baseResp, "headers")) {
+			if _, ok := revHeaders[name]; !ok {
+				c.add(ResponseHeaderRemoved, true, document.Append(basePtr, "headers", name),
+					"response %s header %q removed", status, name)
+			}
+		}
+		c.content(response, "response "+status, basePtr, revPtr, baseResp, revResp)
+	}
+	for _, status := range document.SortedKeys(revResponses) {
+		if _, ok := baseResponses[status]; !ok {
+			c.add(ResponseAdded, false, document.Append(rev.Pointer, "responses", status),
+				"response %s added", status)
+		}
+	}
+}
+
+// content compares the media types of a request body or response.
+func (c *comparer) content(dir direction, where, basePtr, revPtr string, base, rev map[string]any) {
+	baseContent, revContent := document.Map(base, "content"), document.Map(rev, "content")
+	for _, mt := range document.SortedKeys(baseContent) {
+		if _, ok := revContent[mt]; !ok {
+			c.add(MediaTypeRemoved, true, document.Append(basePtr, "content", mt),
+				"%s media type %q removed", where, mt)
+			continue
+		}
+		c.schema(dir, where+" "+mt,
+			document.Append(basePtr, "content", mt, "schema"),
+			document.Append(revPtr, "content", mt, "schema"),
+			document.Map(document.Map(baseContent, mt), "schema"),
+			document.Map(document.Map(revContent, mt), "schema"),
+		)
+	}
+	for _, mt := range document.SortedKeys(revContent) {
+		if _, ok := baseContent[mt]; !ok {
+			c.add(MediaTypeAdded, false, document.Append(revPtr, "content", mt),
+				"%s media type %q added", where, mt)
+		}
+	}
+}
+
+func (c *comparer) security(base, rev document.Operation) {
+	baseReqs := securityRequirements(c.base, base)
+	revReqs := securityRequirements(c.rev, rev)
+	pointer := document.Append(rev.Pointer, "security")
+
+	revSet := map[string]bool{}
+	for _, req := range revReqs {
+		revSet[req] = true
+	}
+	baseSet := map[string]bool{}
+	for _, req := range baseReqs {
+		baseSet[req] = true
+	}
+
+	if len(baseReqs) == 0 {
+		if len(revReqs) > 0 && !revSet[""] {
+			c.add(SecurityChanged, true, pointer, "security requirement %s added", formatRequirements(revReqs))
+		}
+		return
+	}
+	if len(revReqs) == 0 {
+		c.add(SecurityChanged, false, pointer, "security requirements removed")
+		return
+	}
+	if revSet[""] {
+		if !baseSet[""] {
+			c.add(SecurityChanged, false, pointer, "security became optional")
+		}
+		return
+	}
+	for _, req := range baseReqs {
+		if !revSet[req] {
+			c.add(SecurityChanged, true, pointer, "security requirement %s removed", formatRequirement(req))
+		}
+	}
+	for _, req := range revReqs {
+		if !baseSet[req] {
+			c.add(SecurityChanged, false, pointer, "security requirement %s added", formatRequirement(req))
+		}
+	}
+}
+
+// securityRequirements returns the effective requirements of an operation, each encoded
+// as a sorted "scheme(scope,...)+scheme" string. An empty string is the optional {} entry.
+func securityRequirements(doc *document.Document, op document.Operation) []string {
+	list, ok := op.Node["security"].([]any)
+	if !ok {
+		list = document.Slice(doc.Raw(), "security")
+	}
+	reqs := make([]string, 0, len(list))
+	for _, item := range list {
+		req, _ := item.(map[string]any)
+		parts := make([]string, 0, len(req))
+		for _, name := range document.SortedKeys(req) {
+			var scopes []string
+			for _, scope := range document.Slice(req, name) {
+				if s, isString := scope.(string); isString {
+					scopes = append(scopes, s)
+				}
+			}
+			sort.Strings(scopes)
+			part := name
+			if len(scopes) > 0 {
+				part += "(" + strings.Join(scopes, ",") + ")"
+			}
+			parts = append(parts, part)
+		}
+		reqs = append(reqs, strings.Join(parts, "+"))
+	}
+	sort.Strings(reqs)
+	return reqs
+}
+
+func formatRequirement(req string) string {
+	if req == "" {
+		return "{}"
+	}
+	return strconv.Quote(req)
+}
+
+func formatRequirements(reqs []string) string {
+	out := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		out = append(out, formatRequirement(req))
+	}
+	return strings.Join(out, " or ")
+}
@@ -0,0 +1,426 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec/internal/document"
+)
+
+// direction tells whether a schema describes data sent by the client or by the server.
+//
+// Narrowing what the server accepts breaks clients, while widening what it returns does.
+type direction int
+
+const (
+	request direction = iota
+	response
+)
+
+// schemaWalk compares a pair of schemas recursively.
+type schemaWalk struct {
+	c       *comparer
+	dir     direction
+	where   string
+	visited map[string]bool
+}
+
+func (c *comparer) schema(dir direction, where, basePtr, revPtr string, base, rev map[string]any) {
+	w := &schemaWalk{
+		c:       c,
+		dir:     dir,
+		where:   where,
+		visited: map[string]bool{},
+	}
+	w.compare("", basePtr, revPtr, base, rev)
+}
+
+func (w *schemaWalk) label(prop string) string {
+	if prop == "" {
+		return w.where
+	}
+	return w.where + " property " + strconv.Quote(prop)
+}
+
+func (w *schemaWalk) compare(prop, basePtr, revPtr string, base, rev map[string]any) {
+	base, basePtr = w.c.base.Resolve(base, basePtr)
+	rev, revPtr = w.c.rev.Resolve(rev, revPtr)
+	if base == nil || rev == nil {
+		return
+	}
+	key := basePtr + "|" + revPtr
+	if w.visited[key] {
+		return
+	}
+	w.visited[key] = true
+
+	w.types(prop, revPtr, base, rev)
+	w.enum(prop, revPtr, base, rev)
+	w.constraints(prop, revPtr, base, rev)
+	w.required(prop, revPtr, base, rev)
+	w.properties(prop, basePtr, revPtr, base, rev)
+	w.compositions(prop, basePtr, revPtr, base, rev)
+
+	if items, revItems := document.Map(base, "items"), document.Map(rev, "items"); items != nil && revItems != nil {
+		w.compare(prop+"[]", document.Append(basePtr, "items"), document.Append(revPtr, "items"), items, revItems)
+	}
+	additional, revAdditional := document.Map(base, "additionalProperties"), document.Map(rev, "additionalProperties")
+	if additional != nil && revAdditional != nil {
+		w.compare(prop+".*",
+			document.Append(basePtr, "additionalProperties"), document.Append(revPtr, "additionalProperties"),
+			additional, revAdditional)
+	}
+}
+
+// breaking reports whether narrowing (or widening) the schema breaks clients.
+func (w *schemaWalk) breaking(narrowed bool) bool {
+	if w.dir == request {
+		return narrowed
+	}
+	return !narrowed
+}
+
+func (w *schemaWalk) types(prop, pointer string, base, rev map[string]any) {
+	baseTypes, revTypes := schemaTypes(base), schemaTypes(rev)
+	if strings.Join(baseTypes, "|") == strings.Join(revTypes, "|") {
+		return
+	}
+	// A request schema must still accept every base type; a response schema must not
+	// return a type the base did not declare.
+	var compatible bool
+	if w.dir == request {
+		compatible = typesSubset(baseTypes, revTypes)
+	} else {
+		compatible = typesSubset(revTypes, baseTypes)
+	}
+	w.c.add(TypeChanged, !compatible, document.Append(pointer, "type"),
+		"%s type changed from %s to %s", w.label(prop), formatTypes(baseTypes), formatTypes(revTypes))
+}
+
+func (w *schemaWalk) enum(prop, pointer string, base, rev map[string]any) {
+	baseEnum, revEnum := enumValues(base), enumValues(rev)
+	pointer = document.Append(pointer, "enum")
+	switch {
+	case baseEnum == nil && revEnum == nil:
+		return
+	case baseEnum == nil:
+		w.c.add(EnumChanged, w.breaking(true), pointer, "%s enum added", w.label(prop))
+		return
+	case revEnum == nil:
+		w.c.add(EnumChanged, w.breaking(false), pointer, "%s enum removed", w.label(prop))
+		return
+	}
+	if removed := difference(baseEnum, revEnum); len(removed) > 0 {
+		w.c.add(EnumChanged, w.breaking(true), pointer,
+			"%s enum values removed: %s", w.label(prop), strings.Join(removed, ", "))
+	}
+	if added := difference(revEnum, baseEnum); len(added) > 0 {
+		w.c.add(EnumChanged, w.breaking(false), pointer,
+			"%s enum values added: %s", w.label(prop), strings.Join(added, ", "))
+	}
+}
+
+//nolint:gochecknoglobals // read-only lookup table
+var (
+	upperBounds = []string{"maxLength", "maxItems", "maxProperties"}
+	lowerBounds = []string{"minLength", "minItems", "minProperties"}
+)
+
+func (w *schemaWalk) constraints(prop, pointer string, base, rev map[string]any) {
+	for _, key := range []string{"format", "pattern"} {
+		b, r := document.String(base, key), document.String(rev, key)
+		if b == r {
+			continue
+		}
+		// Adding a format or pattern narrows the schema, removing one widens it.
+		narrowed := r != ""
+		kind := ConstraintChanged
+		if key == "format" {
+			kind = FormatChanged
+		}
+		w.c.add(kind, w.breaking(narrowed) || (b != "" && r != ""), document.Append(pointer, key),
+			"%s %s changed from %s to %s", w.label(prop), key, formatValue(b, b != ""), formatValue(r, r != ""))
+	}
+	w.numericBound(prop, pointer, base, rev, true)
+	w.numericBound(prop, pointer, base, rev, false)
+	for _, key := range upperBounds {
+		w.bound(prop, pointer, key, base, rev, true)
+	}
+	for _, key := range lowerBounds {
+		w.bound(prop, pointer, key, base, rev, false)
+	}
+}
+
+func (w *schemaWalk) bound(prop, pointer, key string, base, rev map[string]any, upper bool) {
+	b, hasBase := number(base, key)
+	r, hasRev := number(rev, key)
+	if (!hasBase && !hasRev) || (hasBase && hasRev && b == r) {
+		return
+	}
+	var narrowed bool
+	switch {
+	case !hasBase:
+		narrowed = true
+	case !hasRev:
+		narrowed = false
+	case upper:
+		narrowed = r < b
+	default:
+		narrowed = r > b
+	}
+	w.c.add(ConstraintChanged, w.breaking(narrowed), document.Append(pointer, key),
+		"%s %s changed from %s to %s", w.label(prop), key,
+		formatValue(strconv.FormatFloat(b, 'g', -1, 64), hasBase),
+		formatValue(strconv.FormatFloat(r, 'g', -1, 64), hasRev))
+}
+
+// numericBound compares the maximum or the minimum of two schemas, which may be exclusive.
+func (w *schemaWalk) numericBound(prop, pointer string, base, rev map[string]any, upper bool) {
+	b, baseExclusive, hasBase := effectiveBound(base, upper)
+	r, revExclusive, hasRev := effectiveBound(rev, upper)
+	if (!hasBase && !hasRev) || (hasBase && hasRev && b == r && baseExclusive == revExclusive) {
+		return
+	}
+	var narrowed bool
+	switch {
+	case !hasBase:
+		narrowed = true
+	case !hasRev:
+		narrowed = false
+	case b == r:
+		narrowed = revExclusive
+	case upper:
+		narrowed = r < b
+	default:
+		narrowed = r > b
+	}
+	key, exclusiveKey := "minimum", "exclusiveMinimum"
+	if upper {
+		key, exclusiveKey = "maximum", "exclusiveMaximum"
+	}
+	changed := key
+	if revExclusive || (!hasRev && baseExclusive) {
+		changed = exclusiveKey
+	}
+	w.c.add(ConstraintChanged, w.breaking(narrowed), document.Append(pointer, changed),
+		"%s %s changed from %s to %s", w.label(prop), key,
+		formatValue(formatBound(b, baseExclusive), hasBase), formatValue(formatBound(r, revExclusive), hasRev))
+}
+
+// effectiveBound returns the tightest maximum or minimum of a schema and whether it is
+// exclusive. Exclusive bounds are numbers in OpenAPI 3.1, and booleans that make the
+// sibling maximum or minimum exclusive in OpenAPI 3.0.
+func effectiveBound(node map[string]any, upper bool) (float64, bool, bool) {
+	key, exclusiveKey := "minimum", "exclusiveMinimum"
+	if upper {
+		key, exclusiveKey = "maximum", "exclusiveMaximum"
+	}
+	value, ok := number(node, key)
+	if exclusive, isBool := node[exclusiveKey].(bool); isBool {
+		return value, ok && exclusive, ok
+	}
+	exclusiveValue, hasExclusive := number(node, exclusiveKey)
+	tighter := exclusiveValue <= value
+	if !upper {
+		tighter = exclusiveValue >= value
+	}
+	if hasExclusive && (!ok || tighter) {
+		return exclusiveValue, true, true
+	}
+	return value, false, ok
+}
+
+func formatBound(value float64, exclusive bool) string {
+	s := strconv.FormatFloat(value, 'g', -1, 64)
+	if exclusive {
+		s += " (exclusive)"
+	}
+	return s
+}
+
+func (w *schemaWalk) required(prop, pointer string, base, rev map[string]any) {
+	baseRequired, revRequired := stringSet(base, "required"), stringSet(rev, "required")
+	pointer = document.Append(pointer, "required")
+	for _, name := range document.SortedKeys(revRequired) {
+		if _, ok := baseRequired[name]; !ok {
+			w.c.add(PropertyRequired, w.dir == request, pointer,
+				"%s became required", w.label(joinProp(prop, name)))
+		}
+	}
+	for _, name := range document.SortedKeys(baseRequired) {
+		if _, ok := revRequired[name]; !ok {
+			w.c.add(PropertyOptional, w.dir == response, pointer,
+				"%s became optional", w.label(joinProp(prop, name)))
+		}
+	}
+}
+
+func (w *schemaWalk) properties(prop, basePtr, revPtr string, base, rev map[string]any) {
+	baseProps, revProps := document.Map(base, "properties"), document.Map(rev, "properties")
+	for _, name := range document.SortedKeys(baseProps) {
+		if _, ok := revProps[name]; !ok {
+			// Clients may read any documented response property.
+			w.c.add(PropertyRemoved, w.dir == response, document.Append(basePtr, "properties", name),
+				"%s removed", w.label(joinProp(prop, name)))
+			continue
+		}
+		w.compare(joinProp(prop, name),
+			document.Append(basePtr, "properties", name), document.Append(revPtr, "properties", name),
+			document.Map(baseProps, name), document.Map(revProps, name))
+	}
+	for _, name := range document.SortedKeys(revProps) {
+		if _, ok := baseProps[name]; !ok {
+			w.c.add(PropertyAdded, false, document.Append(revPtr, "properties", name),
+				"%s added", w.label(joinProp(prop, name)))
+		}
+	}
+}
+
+func (w *schemaWalk) compositions(prop, basePtr, revPtr string, base, rev map[string]any) {
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		baseList, revList := document.Slice(base, key), document.Slice(rev, key)
+		if len(baseList) != len(revList) {
+			// More allOf entries narrow the schema; more oneOf/anyOf entries widen it.
+			narrowed := len(revList) > len(baseList)
+			if key != "allOf" {
+				narrowed = !narrowed
+			}
+			w.c.add(CompositionChanged, w.breaking(narrowed), document.Append(revPtr, key),
+				"%s %s changed from %d to %d schemas", w.label(prop), key, len(baseList), len(revList))
+			continue
+		}
+		for i := range baseList {
+			baseItem, _ := baseList[i].(map[string]any)
+			revItem, _ := revList[i].(map[string]any)
+			idx := strconv.Itoa(i)
+			w.compare(prop, document.Append(basePtr, key, idx), document.Append(revPtr, key, idx), baseItem, revItem)
+		}
+	}
+}
+
+// schemaTypes returns the sorted types allowed by a schema, or nil for any type.
+// OpenAPI 3.0 "nullable" is folded into a "null" type so 3.0 and 3.1 compare equally.
+func schemaTypes(schema map[string]any) []string {
+	set := map[string]any{}
+	switch typ := schema["type"].(type) {
+	case string:
+		set[typ] = true
+	case []any:
+		for _, t := range typ {
+			if s, ok := t.(string); ok {
+				set[s] = true
+			}
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	if document.Bool(schema, "nullable") {
+		set["null"] = true
+	}
+	return document.SortedKeys(set)
+}
+
+// typesSubset reports whether every type in a is accepted by b.
+func typesSubset(a, b []string) bool {
+	if len(b) == 0 {
+		return true
+	}
+	if len(a) == 0 {
+		return false
+	}
+	for _, t := range a {
+		if !containsType(b, t) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsType(types []string, t string) bool {
+	for _, typ := range types {
+		if typ == t || (t == "integer" && typ == "number") {
+			return true
+		}
+	}
+	return false
+}
+
+func formatTypes(types []string) string {
+	if len(types) == 0 {
+		return "any"
+	}
+	return strings.Join(types, "|")
+}
+
+func formatValue(value string, ok bool) string {
+	if !ok {
+		return "none"
+	}
+	return value
+}
+
+func enumValues(schema map[string]any) []string {
+	list, ok := schema["enum"].([]any)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		data, err := json.Marshal(item)
+		if err != nil {
+			data = []byte(fmt.Sprint(item))
+		}
+		values = append(values, string(data))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// difference returns the values of a that are not in b.
+func difference(a, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, v := range b {
+		set[v] = true
+	}
+	var out []string
+	for _, v := range a {
+		if !set[v] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func stringSet(node map[string]any, key string) map[string]any {
+	set := map[string]any{}
+	for _, item := range document.Slice(node, key) {
+		if s, ok := item.(string); ok {
+			set[s] = true
+		}
+	}
+	return set
+}
+
+func number(node map[string]any, key string) (float64, bool) {
+	switch v := node[key].(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func joinProp(prop, name string) string {
+	if prop == "" {
+		return name
+	}
+	return prop + "." + name
+}
@@ -0,0 +1,285 @@
+// Package lint checks the OpenAPI document built by a spec.Generator against style rules.
+//
+// Rules report findings such as operations without a summary or components that are
+// never referenced. Every rule has a default severity that can be changed or turned off,
+// and custom rules can be added next to the built-in ones. Both OpenAPI 3.0 and 3.1
+// documents are supported.
+//
+// Example:
+//
+//	report, err := lint.Check(r,
+//		lint.WithSeverity(lint.RuleOperationSummary, lint.Error),
+//		lint.WithSeverity(lint.RulePropertyDescription, lint.Off),
+//	)
+//	require.NoError(t, err)
+//	require.NoError(t, report.Err())
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/internal/document"
+)
+
+// ErrFindings is wrapped by Report.Err when findings with the Error severity are found.
+var ErrFindings = errors.New("lint findings with error severity")
+
+// Severity is the level of a finding. Off disables a rule.
+type Severity int
+
+// Severities in increasing order.
+const (
+	Off Severity = iota
+	Info
+	Warning
+	Error
+)
+
+// String returns the lower-case name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case Off:
+		return "off"
+	case Info:
+		return "info"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return fmt.Sprintf("severity(%d)", int(s))
+	}
+}
+
+// Finding describes a single rule violation.
+type Finding struct {
+	Rule     string   // Name of the rule.
+	Severity Severity // Configured severity of the rule.
+	Method   string   // Upper-case HTTP method, empty for document-level findings.
+	Path     string   // Path template, webhook name or callback expression of the operation.
+	Pointer  string   // JSON pointer to the offending element.
+	Message  string   // Human-readable description.
+}
+
+// String returns a one-line representation of the finding.
+func (f Finding) String() string {
+	var sb strings.Builder
+	sb.WriteString("[" + f.Severity.String() + "] ")
+	if f.Method != "" {
+		sb.WriteString(f.Method + " " + f.Path + ": ")
+	}
+	sb.WriteString(f.Message + " (" + f.Rule + ")")
+	return sb.String()
+}
+
+// Report lists the findings of Check, grouped by rule in the order the rules run and
+// sorted by pointer within each rule.
+type Report struct {
+	Findings []Finding
+}
+
+// AtLeast returns the findings with the given severity or a higher one.
+func (r *Report) AtLeast(severity Severity) []Finding {
+	var out []Finding
+	for _, f := range r.Findings {
+		if f.Severity >= severity {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Err returns an error wrapping ErrFindings that lists every finding with the Error
+// severity, or nil if there are none.
+func (r *Report) Err() error {
+	findings := r.AtLeast(Error)
+	if len(findings) == 0 {
+		return nil
+	}
+	var sb strings.Builder
+	for _, f := range findings {
+		sb.WriteString("\n- ")
+		sb.WriteString(f.String())
+	}
+	return fmt.Errorf("%w:%s", ErrFindings, sb.String())
+}
+
+// String returns all findings, one per line.
+func (r *Report) String() string {
+	lines := make([]string, 0, len(r.Findings))
+	for _, f := range r.Findings {
+		lines = append(lines, f.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Rule checks the document and reports its findings through the context.
+type Rule struct {
+	Name        string
+	Description string
+	Severity    Severity // Default severity, unless changed with WithSeverity.
+	Check       func(c *Context)
+}
+
+type config struct {
+	rules      []Rule
+	severities map[string]Severity
+}
+
+// Option configures Check.
+type Option func(*config)
+
+// WithSeverity changes the severity of the named rule. Off disables the rule.
+func WithSeverity(rule string, severity Severity) Option {
+	return func(c *config) {
+		c.severities[rule] = severity
+	}
+}
+
+// WithRules adds custom rules, which run after the built-in ones.
+func WithRules(rules ...Rule) Option {
+	return func(c *config) {
+		c.rules = append(c.rules, rules...)
+	}
+}
+
+// Check builds the document of the generator and runs the rules against it.
+//
+// It returns an error if the document cannot be built, or if a severity is set for a
+// rule that does not exist.
+func Check(gen spec.Generator, opts ...Option) (*Report, error) {
+	data, err := gen.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build document: %w", err)
+	}
+	return CheckDocument(data, opts...)
+}
+
+// CheckDocument runs the rules against a JSON or YAML OpenAPI document.
+func CheckDocument(data []byte, opts ...Option) (*Report, error) {
+	cfg := &config{
+		rules:      DefaultRules(),
+		severities: map[string]Severity{},
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	known := map[string]bool{}
+	for _, rule := range cfg.rules {
+		known[rule.Name] = true
+	}
+	var unknown []string
+	for name := range cfg.severities {
+		if !known[name] {
+			unknown = append(unknown, strconv.Quote(name))
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("unknown lint rules: %s", strings.Join(unknown, ", "))
+	}
+
+	doc, err := document.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	l := &linter{doc: doc, ops: operations(doc), report: &Report{}}
+	for _, rule := range cfg.rules {
+		severity := rule.Severity
+		if s, ok := cfg.severities[rule.Name]; ok {
+			severity = s
+		}
+		if severity == Off || rule.Check == nil {
+			continue
+		}
+		start := len(l.report.Findings)
+		rule.Check(&Context{linter: l, rule: rule.Name, severity: severity})
+		found := l.report.Findings[start:]
+		sort.SliceStable(found, func(i, j int) bool {
+			return found[i].Pointer < found[j].Pointer
+		})
+	}
+	return l.report, nil
+}
+
+// Operation is an operation of the linted document.
+type Operation struct {
+	Method   string         // Upper-case HTTP method.
+	Path     string         // Path template, webhook name or callback expression.
+	Pointer  string         // JSON pointer to the operation object.
+	Node     map[string]any // The operation object.
+	Outgoing bool           // Whether the API sends the request, as for webhooks and callbacks.
+}
+
+// Context gives a rule access to the document and collects its findings.
+type Context struct {
+	linter   *linter
+	rule     string
+	severity Severity
+}
+
+// Document returns the decoded document, with JSON types. Rules must not modify it.
+func (c *Context) Document() map[string]any {
+	return c.linter.doc.Raw()
+}
+
+// Operations returns the operations under paths and webhooks, followed by their callbacks.
+func (c *Context) Operations() []Operation {
+	return c.linter.ops
+}
+
+// Resolve follows local references starting at node, located at pointer, and returns
+// the resolved object with its pointer.
+func (c *Context) Resolve(node map[string]any, pointer string) (map[string]any, string) {
+	return c.linter.doc.Resolve(node, pointer)
+}
+
+// Report records a document-level finding for the element at pointer.
+func (c *Context) Report(pointer, format string, args ...any) {
+	c.ReportOperation(Operation{}, pointer, format, args...)
+}
+
+// ReportOperation records a finding of the operation for the element at pointer.
+func (c *Context) ReportOperation(op Operation, pointer, format string, args ...any) {
+	c.linter.report.Findings = append(c.linter.report.Findings, Finding{
+		Rule:     c.rule,
+		Severity: c.severity,
+		Method:   op.Method,
+		Path:     op.Path,
+		Pointer:  pointer,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+type linter struct {
+	doc    *document.Document
+	ops    []Operation
+	report *Report
+}
+
+// operations returns the operations under paths and webhooks, followed by their callbacks.
+func operations(doc *document.Document) []Operation {
+	var ops []Operation
+	add := func(found []document.Operation, outgoing bool) {
+		for _, op := range found {
+			ops = append(ops, Operation{
+				Method:   op.Method,
+				Path:     op.Path,
+				Pointer:  op.Pointer,
+				Node:     op.Node,
+				Outgoing: outgoing,
+			})
+		}
+	}
+	add(doc.Operations(), false)
+	add(doc.Webhooks(), true)
+	for i := 0; i < len(ops); i++ {
+		add(doc.Callbacks(document.Operation{Pointer: ops[i].Pointer, Node: ops[i].Node}), true)
+	}
+	return ops
+}
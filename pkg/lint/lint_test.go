@@ -0,0 +1,279 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/openapi"
+	"github.com/oaswrap/spec/option"
+	"github.com/oaswrap/spec/pkg/lint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Pet struct {
+	ID    int      `json:"id"    description:"Pet identifier"`
+	Name  string   `json:"name"`
+	Owner Owner    `json:"owner"`
+	Tags  []string `json:"tags"  description:"Pet tags"`
+}
+
+type Owner struct {
+	Email string `json:"email" description:"Owner email"`
+}
+
+type ErrorBody struct {
+	Message string `json:"message" description:"Error message"`
+}
+
+type GetPet struct {
+	ID int `path:"petId"`
+}
+
+type finding struct {
+	Rule     string
+	Severity lint.Severity
+	Op       string
+	Message  string
+}
+
+func newRouter(version string) spec.Generator {
+	r := spec.NewRouter(
+		option.WithOpenAPIVersion(version),
+		option.WithTags(openapi.Tag{Name: "pets"}),
+		option.WithSecurity("bearerAuth", option.SecurityHTTPBearer("JWT")),
+		option.WithSecurity("apiKey", option.SecurityAPIKey("X-API-Key", openapi.SecuritySchemeAPIKeyInHeader)),
+	)
+	pets := r.Group("/pets", option.GroupTags("pets"), option.GroupSecurity("bearerAuth"))
+	pets.Get("",
+		option.Summary("List pets"),
+		option.OperationID("listPets"),
+		option.Response(200, new([]Pet)),
+		option.Response(400, new(ErrorBody)),
+	)
+	pets.Get("/{petId}",
+		option.Summary("Get pet"),
+		option.OperationID("get_pet"),
+		option.Tags("animals"),
+		option.Request(new(GetPet)),
+		option.Response(200, new(Pet)),
+		option.Response(404, new(ErrorBody)),
+	)
+	r.Get("/health", option.Response(204, nil))
+	return r
+}
+
+func findings(report *lint.Report) []finding {
+	got := make([]finding, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		op := ""
+		if f.Method != "" {
+			op = f.Method + " " + f.Path
+		}
+		got = append(got, finding{f.Rule, f.Severity, op, f.Message})
+	}
+	return got
+}
+
+func TestCheck(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		t.Run(version, func(t *testing.T) {
+			report, err := lint.Check(newRouter(version))
+			require.NoError(t, err)
+
+			assert.Equal(t, []finding{
+				{lint.RuleOperationSummary, lint.Warning, "GET /health", "operation has no summary"},
+				{lint.RuleOperationTags, lint.Warning, "GET /health", "operation has no tags"},
+				{
+					lint.RuleTagDeclared, lint.Warning, "GET /pets/{petId}",
+					`tag "animals" is not declared in the document tags`,
+				},
+				{lint.RuleOperation4xxResponse, lint.Warning, "GET /health", "operation has no 4xx response"},
+				{
+					lint.RuleOperationIDCamelCase, lint.Warning, "GET /pets/{petId}",
+					`operationId "get_pet" is not camelCase`,
+				},
+				{
+					lint.RulePropertyDescription, lint.Info, "",
+					`property "name" of schema "LintTestPet" has no description`,
+				},
+				{lint.RuleUnusedComponent, lint.Warning, "", `component securitySchemes "apiKey" is not used`},
+			}, findings(report))
+			require.NoError(t, report.Err())
+			assert.Len(t, report.AtLeast(lint.Warning), 6)
+		})
+	}
+}
+
+func TestCheck_Severity(t *testing.T) {
+	report, err := lint.Check(newRouter("3.1.0"),
+		lint.WithSeverity(lint.RuleOperationSummary, lint.Error),
+		lint.WithSeverity(lint.RulePropertyDescription, lint.Off),
+		lint.WithSeverity(lint.RuleUnusedComponent, lint.Off),
+		lint.WithSeverity(lint.RuleTagDeclared, lint.Off),
+		lint.WithSeverity(lint.RuleOperationIDCamelCase, lint.Off),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "[error] GET /health: operation has no summary (operation-summary)\n"+
+		"[warning] GET /health: operation has no tags (operation-tags)\n"+
+		"[warning] GET /health: operation has no 4xx response (operation-4xx-response)", report.String())
+	err = report.Err()
+	require.ErrorIs(t, err, lint.ErrFindings)
+	assert.Contains(t, err.Error(), "- [error] GET /health: operation has no summary (operation-summary)")
+	assert.NotContains(t, err.Error(), "operation-tags")
+}
+
+func TestCheck_CustomRule(t *testing.T) {
+	deprecated := lint.Rule{
+		Name:     "operation-deprecated",
+		Severity: lint.Error,
+		Check: func(c *lint.Context) {
+			for _, op := range c.Operations() {
+				if deprecated, _ := op.Node["deprecated"].(bool); deprecated {
+					c.ReportOperation(op, op.Pointer, "deprecated operations must be removed")
+				}
+			}
+		},
+	}
+	r := newRouter("3.0.3")
+	r.Delete("/pets/{petId}", option.Request(new(GetPet)), option.Deprecated())
+
+	report, err := lint.Check(r, lint.WithRules(deprecated), lint.WithSeverity(lint.RuleOperationSummary, lint.Off))
+	require.NoError(t, err)
+	require.ErrorIs(t, report.Err(), lint.ErrFindings)
+
+	errors := report.AtLeast(lint.Error)
+	require.Len(t, errors, 1)
+	assert.Equal(t, lint.Finding{
+		Rule:     "operation-deprecated",
+		Severity: lint.Error,
+		Method:   "DELETE",
+		Path:     "/pets/{petId}",
+		Pointer:  "#/paths/~1pets~1{petId}/delete",
+		Message:  "deprecated operations must be removed",
+	}, errors[0])
+}
+
+func TestCheckDocument(t *testing.T) {
+	const doc = `
+openapi: 3.1.0
+info: {title: API, version: "1"}
+paths:
+  /jobs:
+    post:
+      operationId: createJob
+      summary: Create job
+      tags: [jobs]
+      responses:
+        "202": {description: Accepted}
+        "4XX": {$ref: "#/components/responses/Problem"}
+      callbacks:
+        onDone:
+          "{$request.body#/url}":
+            post:
+              summary: Job done
+              tags: [jobs]
+              responses:
+                "200": {description: OK}
+components:
+  responses:
+    Problem:
+      description: Problem
+      content:
+        application/json:
+          schema: {$ref: "#/components/schemas/Problem/properties/detail"}
+  schemas:
+    Problem:
+      type: object
+      properties:
+        detail: {type: string, description: Detail}
+        meta:
+          type: object
+          description: Metadata
+          properties:
+            code: {type: integer}
+    Unused: {type: string}
+`
+	report, err := lint.CheckDocument([]byte(doc))
+	require.NoError(t, err)
+	assert.Equal(t, []finding{
+		{
+			lint.RuleTagDeclared, lint.Warning, "POST {$request.body#/url}",
+			`tag "jobs" is not declared in the document tags`,
+		},
+		{lint.RuleTagDeclared, lint.Warning, "POST /jobs", `tag "jobs" is not declared in the document tags`},
+		{lint.RulePropertyDescription, lint.Info, "", `property "meta.code" of schema "Problem" has no description`},
+		{lint.RuleUnusedComponent, lint.Warning, "", `component schemas "Unused" is not used`},
+	}, findings(report))
+	assert.Equal(t, "#/paths/~1jobs/post/callbacks/onDone/{$request.body#~1url}/post/tags/0",
+		report.Findings[0].Pointer)
+	assert.Equal(t, "#/paths/~1jobs/post/tags/0", report.Findings[1].Pointer)
+	assert.Equal(t, "#/components/schemas/Problem/properties/meta/properties/code", report.Findings[2].Pointer)
+	assert.Equal(t, "#/components/schemas/Unused", report.Findings[3].Pointer)
+}
+
+func TestCheckDocument_UnusedComponents(t *testing.T) {
+	const doc = `
+openapi: 3.1.0
+info: {title: API, version: "1"}
+paths:
+  /pets:
+    get:
+      responses:
+        "200": {$ref: "#/components/responses/Pets"}
+      callbacks:
+        onChange:
+          "{$request.body#/url}":
+            post:
+              security: [{apiKey: []}]
+              responses:
+                "200": {description: OK}
+components:
+  responses:
+    Pets:
+      description: Pets
+      content:
+        application/json:
+          schema: {type: array, items: {$ref: "#/components/schemas/Pet"}}
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner: {$ref: "#/components/schemas/Owner"}
+    Owner: {type: string}
+    Node:
+      type: object
+      properties:
+        next: {$ref: "#/components/schemas/Node"}
+        tree: {$ref: "#/components/schemas/Tree"}
+    Tree: {type: object}
+  securitySchemes:
+    apiKey: {type: apiKey, name: X-API-Key, in: header}
+    bearer: {type: http, scheme: bearer}
+`
+	report, err := lint.CheckDocument([]byte(doc))
+	require.NoError(t, err)
+	var pointers []string
+	for _, f := range report.Findings {
+		if f.Rule == lint.RuleUnusedComponent {
+			pointers = append(pointers, f.Pointer)
+		}
+	}
+	assert.Equal(t, []string{
+		"#/components/schemas/Node",
+		"#/components/schemas/Tree",
+		"#/components/securitySchemes/bearer",
+	}, pointers)
+}
+
+func TestCheck_Errors(t *testing.T) {
+	_, err := lint.Check(newRouter("3.0.3"), lint.WithSeverity("operation-summry", lint.Error))
+	require.EqualError(t, err, `unknown lint rules: "operation-summry"`)
+
+	_, err = lint.Check(spec.NewRouter(option.WithOpenAPIVersion("2.0")))
+	require.ErrorContains(t, err, "failed to build document")
+
+	_, err = lint.CheckDocument([]byte(`swagger: "2.0"`))
+	require.ErrorContains(t, err, "failed to parse document")
+}
@@ -0,0 +1,203 @@
+package lint
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec/internal/document"
+)
+
+// Names of the built-in rules.
+const (
+	RuleOperationSummary     = "operation-summary"
+	RuleOperationTags        = "operation-tags"
+	RuleTagDeclared          = "operation-tag-declared"
+	RuleOperation4xxResponse = "operation-4xx-response"
+	RuleOperationIDCamelCase = "operation-id-camel-case"
+	RulePropertyDescription  = "schema-property-description"
+	RuleUnusedComponent      = "unused-component"
+)
+
+var reCamelCase = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+
+// DefaultRules returns the built-in rules with their default severities.
+func DefaultRules() []Rule {
+	return []Rule{
+		{
+			Name:        RuleOperationSummary,
+			Description: "Operations have a summary.",
+			Severity:    Warning,
+			Check:       checkOperationSummary,
+		},
+		{
+			Name:        RuleOperationTags,
+			Description: "Operations have at least one tag.",
+			Severity:    Warning,
+			Check:       checkOperationTags,
+		},
+		{
+			Name:        RuleTagDeclared,
+			Description: "Operation tags are declared in the document tags, as with option.WithTags.",
+			Severity:    Warning,
+			Check:       checkTagDeclared,
+		},
+		{
+			Name:        RuleOperation4xxResponse,
+			Description: "Operations served by the API document at least one 4xx response.",
+			Severity:    Warning,
+			Check:       checkOperation4xxResponse,
+		},
+		{
+			Name:        RuleOperationIDCamelCase,
+			Description: "Operation IDs are camelCase.",
+			Severity:    Warning,
+			Check:       checkOperationIDCamelCase,
+		},
+		{
+			Name:        RulePropertyDescription,
+			Description: "Properties of component schemas have a description.",
+			Severity:    Info,
+			Check:       checkPropertyDescription,
+		},
+		{
+			Name:        RuleUnusedComponent,
+			Description: "Components are reachable from the operations of the document.",
+			Severity:    Warning,
+			Check:       checkUnusedComponent,
+		},
+	}
+}
+
+func checkOperationSummary(c *Context) {
+	for _, op := range c.Operations() {
+		if document.String(op.Node, "summary") == "" {
+			c.ReportOperation(op, op.Pointer, "operation has no summary")
+		}
+	}
+}
+
+func checkOperationTags(c *Context) {
+	for _, op := range c.Operations() {
+		if len(document.Slice(op.Node, "tags")) == 0 {
+			c.ReportOperation(op, op.Pointer, "operation has no tags")
+		}
+	}
+}
+
+func checkTagDeclared(c *Context) {
+	declared := map[string]bool{}
+	for _, item := range document.Slice(c.Document(), "tags") {
+		tag, _ := item.(map[string]any)
+		declared[document.String(tag, "name")] = true
+	}
+	for _, op := range c.Operations() {
+		for i, tag := range document.Slice(op.Node, "tags") {
+			if name, _ := tag.(string); !declared[name] {
+				c.ReportOperation(op, document.Append(op.Pointer, "tags", strconv.Itoa(i)),
+					"tag %q is not declared in the document tags", name)
+			}
+		}
+	}
+}
+
+func checkOperation4xxResponse(c *Context) {
+	for _, op := range c.Operations() {
+		if op.Outgoing {
+			continue
+		}
+		found := false
+		for status := range document.Map(op.Node, "responses") {
+			found = found || strings.HasPrefix(status, "4")
+		}
+		if !found {
+			c.ReportOperation(op, document.Append(op.Pointer, "responses"), "operation has no 4xx response")
+		}
+	}
+}
+
+func checkOperationIDCamelCase(c *Context) {
+	for _, op := range c.Operations() {
+		id := document.String(op.Node, "operationId")
+		if id != "" && !reCamelCase.MatchString(id) {
+			c.ReportOperation(op, document.Append(op.Pointer, "operationId"), "operationId %q is not camelCase", id)
+		}
+	}
+}
+
+func checkPropertyDescription(c *Context) {
+	schemas := document.Map(document.Map(c.Document(), "components"), "schemas")
+	for _, name := range document.SortedKeys(schemas) {
+		checkProperties(c, name, "", document.Map(schemas, name), document.Pointer("components", "schemas", name))
+	}
+}
+
+// checkProperties reports the properties of an inline schema without a description,
+// including those of nested objects, array items and composed schemas. Referenced
+// schemas are checked as components of their own.
+func checkProperties(c *Context, schemaName, prefix string, schema map[string]any, pointer string) {
+	if schema == nil || schema["$ref"] != nil {
+		return
+	}
+	properties := document.Map(schema, "properties")
+	for _, name := range document.SortedKeys(properties) {
+		property := document.Map(properties, name)
+		propPtr := document.Append(pointer, "properties", name)
+		if property == nil || property["$ref"] != nil {
+			continue
+		}
+		if document.String(property, "description") == "" {
+			c.Report(propPtr, "property %q of schema %q has no description", prefix+name, schemaName)
+		}
+		checkProperties(c, schemaName, prefix+name+".", property, propPtr)
+	}
+	checkProperties(c, schemaName, prefix, document.Map(schema, "items"), document.Append(pointer, "items"))
+	checkProperties(c, schemaName, prefix, document.Map(schema, "additionalProperties"),
+		document.Append(pointer, "additionalProperties"))
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		for i, item := range document.Slice(schema, key) {
+			sub, _ := item.(map[string]any)
+			checkProperties(c, schemaName, prefix, sub, document.Append(pointer, key, strconv.Itoa(i)))
+		}
+	}
+}
+
+// checkUnusedComponent reports components that are not reachable from the paths,
+// webhooks and other top-level fields through references, and security schemes that no
+// security requirement uses. A component only referenced by unused components, including
+// itself, is unused as well.
+func checkUnusedComponent(c *Context) {
+	doc := c.Document()
+	var roots []any
+	for _, key := range document.SortedKeys(doc) {
+		if key != "components" {
+			roots = append(roots, doc[key])
+		}
+	}
+	used := c.linter.doc.ReachableComponents(roots...)
+	collectSecurity(document.Slice(doc, "security"), used)
+	for _, op := range c.Operations() {
+		collectSecurity(document.Slice(op.Node, "security"), used)
+	}
+
+	components := document.Map(doc, "components")
+	for _, section := range document.SortedKeys(components) {
+		entries := document.Map(components, section)
+		for _, name := range document.SortedKeys(entries) {
+			pointer := document.Pointer("components", section, name)
+			if !used[pointer] {
+				c.Report(pointer, "component %s %q is not used", section, name)
+			}
+		}
+	}
+}
+
+// collectSecurity records the security schemes used by security requirements.
+func collectSecurity(requirements []any, used map[string]bool) {
+	for _, item := range requirements {
+		requirement, _ := item.(map[string]any)
+		for name := range requirement {
+			used[document.Pointer("components", "securitySchemes", name)] = true
+		}
+	}
+}
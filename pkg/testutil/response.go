@@ -0,0 +1,22 @@
+package testutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/pkg/validator"
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertResponse asserts that the recorded response to req is documented by gen.
+//
+// The status code, Content-Type header and JSON body must match one of the responses
+// declared with option.Response for the operation that handles req.
+// It returns true if the response conforms.
+func AssertResponse(t *testing.T, gen spec.Generator, rec *httptest.ResponseRecorder, req *http.Request) bool {
+	t.Helper()
+	err := validator.NewResponseValidator(gen).Validate(req, rec.Code, rec.Header(), rec.Body.Bytes())
+	return assert.NoError(t, err, "response does not conform to the OpenAPI spec")
+}
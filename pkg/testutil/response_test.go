@@ -0,0 +1,74 @@
+package testutil_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/option"
+	"github.com/oaswrap/spec/pkg/testutil"
+)
+
+type User struct {
+	ID   int    `json:"id"   required:"true"`
+	Name string `json:"name" required:"true"`
+}
+
+func TestAssertResponse(t *testing.T) {
+	r := spec.NewRouter()
+	r.Get("/users/{id}",
+		option.Request(new(struct {
+			ID int `path:"id"`
+		})),
+		option.Response(200, new(User)),
+	)
+
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		shouldErr bool
+	}{
+		{
+			name: "documented response",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(User{ID: 1, Name: "John"})
+			},
+		},
+		{
+			name: "undocumented status code",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusTeapot)
+			},
+			shouldErr: true,
+		},
+		{
+			name: "body does not match schema",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":"1"}`))
+			},
+			shouldErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+			rec := httptest.NewRecorder()
+			tt.handler(rec, req)
+
+			mockT := &testing.T{}
+			ok := testutil.AssertResponse(mockT, r, rec, req)
+
+			if tt.shouldErr && (ok || !mockT.Failed()) {
+				t.Error("Expected test to fail but it passed")
+			}
+			if !tt.shouldErr && (!ok || mockT.Failed()) {
+				t.Error("Expected test to pass but it failed")
+			}
+		})
+	}
+}
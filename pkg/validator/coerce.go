@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oaswrap/spec/internal/document"
+)
+
+var reJSONNumber = regexp.MustCompile(`^-?(0|[1-9]\d*)(\.\d+)?([eE][+-]?\d+)?$`)
+
+// coerceParameter converts raw parameter strings into the JSON value described by schema,
+// following the parameter serialization style.
+func coerceParameter(doc *document.Document, schema map[string]any, param document.Parameter, values []string) any {
+	switch doc.SchemaType(schema) {
+	case "array":
+		items := itemsSchema(doc, schema)
+		parts := values
+		if len(values) == 1 && !(param.Explode && param.Style == "form") {
+			parts = strings.Split(values[0], delimiter(param.Style))
+		}
+		result := make([]any, 0, len(parts))
+		for _, part := range parts {
+			result = append(result, coerceScalar(doc, items, part))
+		}
+		return result
+	case "object":
+		if param.Style == "deepObject" {
+			return coerceObject(doc, schema, values)
+		}
+		return decodeJSONOrString(values[0])
+	default:
+		return coerceScalar(doc, schema, values[0])
+	}
+}
+
+// coerceForm converts URL-encoded form values into an object described by schema.
+func coerceForm(doc *document.Document, schema map[string]any, form url.Values) map[string]any {
+	resolved, _ := doc.Resolve(schema, "")
+	props := document.Map(resolved, "properties")
+	result := make(map[string]any, len(form))
+	for key, values := range form {
+		prop := document.Map(props, key)
+		if doc.SchemaType(prop) == "array" {
+			items := itemsSchema(doc, prop)
+			list := make([]any, 0, len(values))
+			for _, val := range values {
+				list = append(list, coerceScalar(doc, items, val))
+			}
+			result[key] = list
+			continue
+		}
+		result[key] = coerceScalar(doc, prop, values[0])
+	}
+	return result
+}
+
+// coerceObject builds an object from "key=value" pairs produced by deepObject lookups.
+func coerceObject(doc *document.Document, schema map[string]any, pairs []string) map[string]any {
+	resolved, _ := doc.Resolve(schema, "")
+	props := document.Map(resolved, "properties")
+	additional, _ := resolved["additionalProperties"].(map[string]any)
+	result := make(map[string]any, len(pairs))
+	for _, pair := range pairs {
+		key, val, _ := strings.Cut(pair, "=")
+		prop := document.Map(props, key)
+		if prop == nil {
+			prop = additional
+		}
+		result[key] = coerceScalar(doc, prop, val)
+	}
+	return result
+}
+
+func coerceScalar(doc *document.Document, schema map[string]any, raw string) any {
+	switch doc.SchemaType(schema) {
+	case "integer", "number":
+		if reJSONNumber.MatchString(raw) {
+			return json.Number(raw)
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(raw); err == nil {
+			return b
+		}
+	case "object", "array":
+		return decodeJSONOrString(raw)
+	}
+	return raw
+}
+
+func itemsSchema(doc *document.Document, schema map[string]any) map[string]any {
+	resolved, _ := doc.Resolve(schema, "")
+	return document.Map(resolved, "items")
+}
+
+func decodeJSONOrString(raw string) any {
+	dec := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil || dec.More() {
+		return raw
+	}
+	return value
+}
+
+func delimiter(style string) string {
+	switch style {
+	case "spaceDelimited":
+		return " "
+	case "pipeDelimited":
+		return "|"
+	default:
+		return ","
+	}
+}
@@ -0,0 +1,69 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Error is returned when a request or response does not match its OpenAPI operation.
+//
+// It is rendered as the JSON body of the 400 response written by the middleware.
+type Error struct {
+	Status  int          `json:"status"`
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes a single invalid input.
+type FieldError struct {
+	In      string `json:"in"`                // Location of the input: path, query, header, cookie, body or status.
+	Name    string `json:"name,omitempty"`    // Parameter name, empty for the body.
+	Pointer string `json:"pointer,omitempty"` // JSON pointer to the invalid value inside the input.
+	Message string `json:"message"`           // Description of the failure.
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if len(e.Errors) == 0 {
+		return e.Message
+	}
+	parts := make([]string, 0, len(e.Errors))
+	for _, fe := range e.Errors {
+		parts = append(parts, fe.String())
+	}
+	return e.Message + ": " + strings.Join(parts, "; ")
+}
+
+// String returns a compact representation of the field error.
+func (fe FieldError) String() string {
+	location := fe.In
+	if fe.Name != "" {
+		location += " " + fe.Name
+	}
+	if fe.Pointer != "" {
+		location += " " + fe.Pointer
+	}
+	return location + ": " + fe.Message
+}
+
+// ErrorHandler writes the response for a request that failed validation.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// DefaultErrorHandler writes err as a JSON problem response.
+//
+// Validation errors produce a 400 Bad Request with the list of invalid fields.
+// Any other error, such as a spec that fails to build, produces a 500.
+func DefaultErrorHandler(w http.ResponseWriter, _ *http.Request, err error) {
+	var body *Error
+	if !errors.As(err, &body) {
+		body = &Error{
+			Status:  http.StatusInternalServerError,
+			Message: err.Error(),
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(body.Status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,177 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/internal/document"
+)
+
+// ResponseValidator checks that handlers return the responses they document.
+//
+// It is meant for tests, where a recorded response can be compared with the
+// responses declared through option.Response for the matching operation.
+type ResponseValidator struct {
+	src *source
+}
+
+// NewResponseValidator returns a ResponseValidator for the given generator.
+func NewResponseValidator(gen spec.Generator) *ResponseValidator {
+	return &ResponseValidator{
+		src: &source{gen: gen},
+	}
+}
+
+// Validate checks a response to r against the operation matching r.
+//
+// The status code must be declared, either exactly, as a range such as "2XX" or as
+// the default response. The Content-Type header must match one of the declared media
+// types, and JSON bodies must conform to the declared schema. Required response
+// headers must be present and valid.
+//
+// It returns an *Error describing every mismatch, or nil if the response conforms.
+func (v *ResponseValidator) Validate(r *http.Request, status int, header http.Header, body []byte) error {
+	doc, err := v.src.document()
+	if err != nil {
+		return err
+	}
+	op, _, ok := doc.Match(r.Method, r.URL.Path)
+	if !ok {
+		return fmt.Errorf("no operation documented for %s %s", r.Method, r.URL.Path)
+	}
+	invalid := func(fieldErrors ...FieldError) error {
+		return &Error{
+			Status:  http.StatusInternalServerError,
+			Message: fmt.Sprintf("invalid response %d for %s %s", status, op.Method, op.Path),
+			Errors:  fieldErrors,
+		}
+	}
+
+	response, pointer, ok := doc.Response(op, status)
+	if !ok {
+		return invalid(FieldError{
+			In:      "status",
+			Message: fmt.Sprintf("status code %d is not documented", status),
+		})
+	}
+
+	fieldErrors, err := validateResponseHeaders(doc, response, pointer, header)
+	if err != nil {
+		return err
+	}
+	errs, err := validateResponseBody(doc, r, response, pointer, header, body)
+	if err != nil {
+		return err
+	}
+	fieldErrors = append(fieldErrors, errs...)
+
+	if len(fieldErrors) > 0 {
+		return invalid(fieldErrors...)
+	}
+	return nil
+}
+
+func validateResponseHeaders(
+	doc *document.Document,
+	response map[string]any,
+	pointer string,
+	header http.Header,
+) ([]FieldError, error) {
+	headers := document.Map(response, "headers")
+
+	var fieldErrors []FieldError
+	for _, name := range document.SortedKeys(headers) {
+		if strings.EqualFold(name, "Content-Type") {
+			continue // Ignored by the specification, checked against the content map instead.
+		}
+		node, nodePtr := doc.Resolve(document.Map(headers, name), document.Append(pointer, "headers", name))
+		values := header.Values(name)
+		if len(values) == 0 {
+			if document.Bool(node, "required") {
+				fieldErrors = append(fieldErrors, FieldError{In: "header", Name: name, Message: "header is required"})
+			}
+			continue
+		}
+		schema := document.Map(node, "schema")
+		if schema == nil {
+			continue
+		}
+		param := document.Parameter{Name: name, In: "header", Style: "simple"}
+		violations, err := doc.ValidateSchema(
+			document.Append(nodePtr, "schema"),
+			coerceParameter(doc, schema, param, values),
+		)
+		if err != nil {
+			return nil, err
+		}
+		for _, violation := range violations {
+			fieldErrors = append(fieldErrors, FieldError{
+				In:      "header",
+				Name:    name,
+				Pointer: violation.Pointer,
+				Message: violation.Message,
+			})
+		}
+	}
+	return fieldErrors, nil
+}
+
+func validateResponseBody(
+	doc *document.Document,
+	r *http.Request,
+	response map[string]any,
+	pointer string,
+	header http.Header,
+	body []byte,
+) ([]FieldError, error) {
+	content := document.Map(response, "content")
+	if len(content) == 0 {
+		if len(body) > 0 {
+			return []FieldError{{In: "body", Message: "response body is not documented"}}, nil
+		}
+		return nil, nil
+	}
+	if len(body) == 0 {
+		if r.Method == http.MethodHead {
+			return nil, nil
+		}
+		return []FieldError{{In: "body", Message: "response body is empty"}}, nil
+	}
+
+	contentType := header.Get("Content-Type")
+	mediaType, ok := document.MatchMediaType(content, contentType)
+	if !ok {
+		return []FieldError{{
+			In:      "header",
+			Name:    "Content-Type",
+			Message: fmt.Sprintf("content type %q is not documented", contentType),
+		}}, nil
+	}
+	if document.Map(document.Map(content, mediaType), "schema") == nil || !document.IsJSONMediaType(contentType) {
+		return nil, nil // Only JSON bodies are validated against their schema.
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return []FieldError{{In: "body", Message: "invalid JSON: " + err.Error()}}, nil
+	}
+	violations, err := doc.ValidateSchema(document.Append(pointer, "content", mediaType, "schema"), value)
+	if err != nil {
+		return nil, err
+	}
+	fieldErrors := make([]FieldError, 0, len(violations))
+	for _, violation := range violations {
+		fieldErrors = append(fieldErrors, FieldError{
+			In:      "body",
+			Pointer: violation.Pointer,
+			Message: violation.Message,
+		})
+	}
+	return fieldErrors, nil
+}
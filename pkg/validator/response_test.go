@@ -0,0 +1,180 @@
+package validator_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/option"
+	"github.com/oaswrap/spec/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ErrorResponse struct {
+	Message string `json:"message" required:"true"`
+}
+
+func newResponseGenerator(version string) spec.Generator {
+	r := spec.NewRouter(
+		option.WithOpenAPIVersion(version),
+		option.WithTitle("Validator API"),
+		option.WithVersion("1.0.0"),
+	)
+	r.Get("/pets/{petId}",
+		option.Request(new(GetPetRequest)),
+		option.Response(200, new(Pet)),
+		option.Response(404, new(ErrorResponse)),
+	)
+	r.Get("/pets/{petId}/photo",
+		option.Request(new(GetPetRequest)),
+		option.Response(200, new([]byte), option.ContentType("image/png")),
+	)
+	r.Delete("/pets/{petId}",
+		option.Request(new(GetPetRequest)),
+		option.Response(204, nil),
+	)
+	return r
+}
+
+func TestResponseValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		status      int
+		contentType string
+		body        string
+		wantErr     []validator.FieldError
+	}{
+		{
+			name:        "valid JSON response",
+			method:      http.MethodGet,
+			target:      "/pets/1",
+			status:      http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+			body:        `{"id":1,"name":"Rex"}`,
+		},
+		{
+			name:        "valid error response",
+			method:      http.MethodGet,
+			target:      "/pets/1",
+			status:      http.StatusNotFound,
+			contentType: "application/json",
+			body:        `{"message":"not found"}`,
+		},
+		{
+			name:        "undocumented status code",
+			method:      http.MethodGet,
+			target:      "/pets/1",
+			status:      http.StatusInternalServerError,
+			contentType: "application/json",
+			body:        `{"message":"boom"}`,
+			wantErr: []validator.FieldError{
+				{In: "status", Message: "status code 500 is not documented"},
+			},
+		},
+		{
+			name:        "body does not match schema",
+			method:      http.MethodGet,
+			target:      "/pets/1",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"id":"1","status":"lost"}`,
+			wantErr: []validator.FieldError{
+				{In: "body", Message: "missing properties: 'name'"},
+				{In: "body", Pointer: "/id", Message: "expected integer, but got string"},
+				{In: "body", Pointer: "/status", Message: `value must be one of "available", "pending", "sold"`},
+			},
+		},
+		{
+			name:        "undocumented content type",
+			method:      http.MethodGet,
+			target:      "/pets/1",
+			status:      http.StatusOK,
+			contentType: "text/plain",
+			body:        `Rex`,
+			wantErr: []validator.FieldError{
+				{In: "header", Name: "Content-Type", Message: `content type "text/plain" is not documented`},
+			},
+		},
+		{
+			name:        "malformed JSON",
+			method:      http.MethodGet,
+			target:      "/pets/1",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			body:        `{"name":`,
+			wantErr: []validator.FieldError{
+				{In: "body", Message: "invalid JSON: unexpected EOF"},
+			},
+		},
+		{
+			name:        "missing body",
+			method:      http.MethodGet,
+			target:      "/pets/1",
+			status:      http.StatusOK,
+			contentType: "application/json",
+			wantErr: []validator.FieldError{
+				{In: "body", Message: "response body is empty"},
+			},
+		},
+		{
+			name:        "binary body is not schema checked",
+			method:      http.MethodGet,
+			target:      "/pets/1/photo",
+			status:      http.StatusOK,
+			contentType: "image/png",
+			body:        "\x89PNG",
+		},
+		{
+			name:   "empty response",
+			method: http.MethodDelete,
+			target: "/pets/1",
+			status: http.StatusNoContent,
+		},
+		{
+			name:        "undocumented body",
+			method:      http.MethodDelete,
+			target:      "/pets/1",
+			status:      http.StatusNoContent,
+			contentType: "application/json",
+			body:        `{}`,
+			wantErr: []validator.FieldError{
+				{In: "body", Message: "response body is not documented"},
+			},
+		},
+	}
+
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		v := validator.NewResponseValidator(newResponseGenerator(version))
+		for _, tt := range tests {
+			t.Run(version+"/"+tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, tt.target, nil)
+				header := http.Header{}
+				if tt.contentType != "" {
+					header.Set("Content-Type", tt.contentType)
+				}
+
+				err := v.Validate(req, tt.status, header, []byte(tt.body))
+				if len(tt.wantErr) == 0 {
+					require.NoError(t, err)
+					return
+				}
+				var verr *validator.Error
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, http.StatusInternalServerError, verr.Status)
+				assert.ElementsMatch(t, tt.wantErr, verr.Errors)
+			})
+		}
+	}
+}
+
+func TestResponseValidator_UnknownOperation(t *testing.T) {
+	v := validator.NewResponseValidator(newResponseGenerator("3.0.3"))
+	req := httptest.NewRequest(http.MethodGet, "/owners", nil)
+
+	err := v.Validate(req, http.StatusOK, http.Header{}, nil)
+	require.EqualError(t, err, "no operation documented for GET /owners")
+}
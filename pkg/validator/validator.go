@@ -0,0 +1,311 @@
+// Package validator checks HTTP traffic against the OpenAPI document built by a spec.Generator.
+//
+// The request validator turns the structures passed to option.Request into runtime
+// checks, so the documented contract and the enforced one cannot drift apart.
+//
+// Example:
+//
+//	r := spec.NewRouter()
+//	r.Post("/pets", option.Request(new(CreatePetRequest)))
+//
+//	v := validator.NewRequestValidator(r)
+//	http.ListenAndServe(":8080", v.Middleware(mux))
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/internal/document"
+)
+
+type config struct {
+	errorHandler ErrorHandler
+}
+
+// Option configures a RequestValidator.
+type Option func(*config)
+
+// WithErrorHandler sets the handler used to write responses for invalid requests.
+//
+// The default is DefaultErrorHandler.
+func WithErrorHandler(handler ErrorHandler) Option {
+	return func(c *config) {
+		c.errorHandler = handler
+	}
+}
+
+// RequestValidator validates incoming requests against the registered operations.
+//
+// The OpenAPI document is built on the first validated request and again once routes
+// change, so the validator can be created before any route is registered. Requests that do not match a documented
+// operation are passed through untouched.
+type RequestValidator struct {
+	src *source
+	cfg *config
+}
+
+// NewRequestValidator returns a RequestValidator for the given generator.
+func NewRequestValidator(gen spec.Generator, opts ...Option) *RequestValidator {
+	cfg := &config{
+		errorHandler: DefaultErrorHandler,
+	}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return &RequestValidator{
+		src: &source{gen: gen},
+		cfg: cfg,
+	}
+}
+
+// Middleware returns a net/http middleware that rejects invalid requests.
+func (v *RequestValidator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := v.Validate(r); err != nil {
+			v.HandleError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandleError writes the response for err using the configured error handler.
+func (v *RequestValidator) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	v.cfg.errorHandler(w, r, err)
+}
+
+// Validate checks the path, query, header and cookie parameters and the request body
+// of r against the matching operation.
+//
+// It returns an *Error describing every invalid input, or nil if the request is valid
+// or not documented. The request body is restored so handlers can read it again.
+func (v *RequestValidator) Validate(r *http.Request) error {
+	doc, err := v.src.document()
+	if err != nil {
+		return err
+	}
+	op, pathValues, ok := doc.Match(r.Method, r.URL.Path)
+	if !ok {
+		return nil
+	}
+
+	var fieldErrors []FieldError
+	for _, param := range op.Parameters {
+		errs, perr := validateParameter(doc, r, param, pathValues)
+		if perr != nil {
+			return perr
+		}
+		fieldErrors = append(fieldErrors, errs...)
+	}
+	errs, err := validateBody(doc, r, op)
+	if err != nil {
+		return err
+	}
+	fieldErrors = append(fieldErrors, errs...)
+
+	if len(fieldErrors) > 0 {
+		return &Error{
+			Status:  http.StatusBadRequest,
+			Message: fmt.Sprintf("invalid request for %s %s", op.Method, op.Path),
+			Errors:  fieldErrors,
+		}
+	}
+	return nil
+}
+
+// source lazily builds and parses the OpenAPI document of a generator, and parses it
+// again when the generator builds a new spec, so routes registered later are validated.
+type source struct {
+	gen spec.Generator
+
+	mu     sync.Mutex
+	parsed bool
+	built  any // Document of the generator the cached document was parsed from.
+	doc    *document.Document
+	err    error
+}
+
+func (s *source) document() (*document.Document, error) {
+	built := s.gen.Document()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.parsed && built == s.built {
+		return s.doc, s.err
+	}
+	s.parsed, s.built, s.doc = true, built, nil
+	data, err := s.gen.MarshalJSON()
+	if err != nil {
+		s.err = fmt.Errorf("failed to build OpenAPI document: %w", err)
+		return nil, s.err
+	}
+	s.doc, s.err = document.Parse(data)
+	return s.doc, s.err
+}
+
+func validateParameter(
+	doc *document.Document,
+	r *http.Request,
+	param document.Parameter,
+	pathValues map[string]string,
+) ([]FieldError, error) {
+	values, present := lookupParameter(r, param, pathValues)
+	if !present {
+		if param.Required {
+			return []FieldError{{In: param.In, Name: param.Name, Message: "parameter is required"}}, nil
+		}
+		return nil, nil
+	}
+	schema, pointer := param.Schema()
+	if schema == nil {
+		return nil, nil
+	}
+
+	var value any
+	if _, isContent := param.Node["content"]; isContent {
+		value = decodeJSONOrString(values[0])
+	} else {
+		value = coerceParameter(doc, schema, param, values)
+	}
+
+	violations, err := doc.ValidateSchema(pointer, value)
+	if err != nil {
+		return nil, err
+	}
+	fieldErrors := make([]FieldError, 0, len(violations))
+	for _, violation := range violations {
+		fieldErrors = append(fieldErrors, FieldError{
+			In:      param.In,
+			Name:    param.Name,
+			Pointer: violation.Pointer,
+			Message: violation.Message,
+		})
+	}
+	return fieldErrors, nil
+}
+
+func lookupParameter(r *http.Request, param document.Parameter, pathValues map[string]string) ([]string, bool) {
+	switch param.In {
+	case "path":
+		value, ok := pathValues[param.Name]
+		return []string{value}, ok
+	case "query":
+		query := r.URL.Query()
+		if param.Style == "deepObject" {
+			return deepObjectValues(query, param.Name)
+		}
+		values, ok := query[param.Name]
+		return values, ok
+	case "header":
+		values := r.Header.Values(param.Name)
+		return values, len(values) > 0
+	case "cookie":
+		cookie, err := r.Cookie(param.Name)
+		if err != nil {
+			return nil, false
+		}
+		return []string{cookie.Value}, true
+	}
+	return nil, false
+}
+
+// deepObjectValues flattens "name[key]=value" pairs into "key=value" strings.
+func deepObjectValues(query url.Values, name string) ([]string, bool) {
+	var values []string
+	for key, vals := range query {
+		if !strings.HasPrefix(key, name+"[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		prop := key[len(name)+1 : len(key)-1]
+		for _, val := range vals {
+			values = append(values, prop+"="+val)
+		}
+	}
+	return values, len(values) > 0
+}
+
+func validateBody(doc *document.Document, r *http.Request, op document.Operation) ([]FieldError, error) {
+	body := document.Map(op.Node, "requestBody")
+	if body == nil {
+		return nil, nil
+	}
+	body, pointer := doc.Resolve(body, document.Append(op.Pointer, "requestBody"))
+
+	data, err := readBody(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		if document.Bool(body, "required") {
+			return []FieldError{{In: "body", Message: "request body is required"}}, nil
+		}
+		return nil, nil
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return []FieldError{{In: "body", Message: "Content-Type header is required"}}, nil
+	}
+	content := document.Map(body, "content")
+	mediaType, ok := document.MatchMediaType(content, contentType)
+	if !ok {
+		return []FieldError{{In: "body", Message: fmt.Sprintf("unsupported content type %q", contentType)}}, nil
+	}
+	schema := document.Map(document.Map(content, mediaType), "schema")
+	if schema == nil {
+		return nil, nil
+	}
+
+	var value any
+	switch {
+	case document.IsJSONMediaType(contentType):
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err = dec.Decode(&value); err != nil {
+			return []FieldError{{In: "body", Message: "invalid JSON: " + err.Error()}}, nil
+		}
+	case strings.HasPrefix(contentType, "application/x-www-form-urlencoded"):
+		form, ferr := url.ParseQuery(string(data))
+		if ferr != nil {
+			return []FieldError{{In: "body", Message: "invalid form: " + ferr.Error()}}, nil
+		}
+		value = coerceForm(doc, schema, form)
+	default:
+		return nil, nil // Only JSON and URL-encoded bodies are validated.
+	}
+
+	violations, err := doc.ValidateSchema(document.Append(pointer, "content", mediaType, "schema"), value)
+	if err != nil {
+		return nil, err
+	}
+	fieldErrors := make([]FieldError, 0, len(violations))
+	for _, violation := range violations {
+		fieldErrors = append(fieldErrors, FieldError{
+			In:      "body",
+			Pointer: violation.Pointer,
+			Message: violation.Message,
+		})
+	}
+	return fieldErrors, nil
+}
+
+func readBody(r *http.Request) ([]byte, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(r.Body)
+	_ = r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
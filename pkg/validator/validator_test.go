@@ -0,0 +1,317 @@
+package validator_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/oaswrap/spec"
+	"github.com/oaswrap/spec/option"
+	"github.com/oaswrap/spec/pkg/validator"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Pet struct {
+	ID     int      `json:"id"`
+	Name   string   `json:"name"             required:"true" minLength:"2"`
+	Status string   `json:"status,omitempty" enum:"available,pending,sold"`
+	Tags   []string `json:"tags,omitempty"   maxItems:"2"`
+}
+
+type ListPetsRequest struct {
+	Limit  int      `query:"limit"  minimum:"1" maximum:"100"`
+	Sort   string   `query:"sort"   enum:"name,age"`
+	Status []string `query:"status" maxItems:"2"`
+	Trace  string   `header:"X-Trace-Id" required:"true"`
+}
+
+type GetPetRequest struct {
+	ID      int    `path:"petId"    minimum:"1"`
+	Session string `cookie:"session"`
+}
+
+type CreatePetRequest struct {
+	Pet
+
+	Tenant string `path:"tenant" pattern:"^[a-z]+$"`
+}
+
+type LoginForm struct {
+	Username string `formData:"username" required:"true"`
+	Remember bool   `formData:"remember"`
+}
+
+func newGenerator(version string) spec.Generator {
+	r := spec.NewRouter(
+		option.WithOpenAPIVersion(version),
+		option.WithTitle("Validator API"),
+		option.WithVersion("1.0.0"),
+	)
+	r.Get("/pets",
+		option.Request(new(ListPetsRequest)),
+		option.Response(200, new([]Pet)),
+	)
+	r.Get("/pets/mine",
+		option.Response(200, new([]Pet)),
+	)
+	r.Get("/pets/{petId}",
+		option.Request(new(GetPetRequest)),
+		option.Response(200, new(Pet)),
+	)
+	r.Post("/tenants/{tenant}/pets",
+		option.Request(new(CreatePetRequest)),
+		option.Response(201, new(Pet)),
+	)
+	r.Post("/login",
+		option.Request(new(LoginForm)),
+		option.Response(204, nil),
+	)
+	return r
+}
+
+func TestRequestValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		body    string
+		header  map[string]string
+		cookie  *http.Cookie
+		wantErr []validator.FieldError
+	}{
+		{
+			name:   "valid query and header",
+			method: http.MethodGet,
+			target: "/pets?limit=10&sort=age&status=available&status=sold",
+			header: map[string]string{"X-Trace-Id": "abc"},
+		},
+		{
+			name:   "missing required header",
+			method: http.MethodGet,
+			target: "/pets",
+			wantErr: []validator.FieldError{
+				{In: "header", Name: "X-Trace-Id", Message: "parameter is required"},
+			},
+		},
+		{
+			name:   "query out of range, wrong enum and too many items",
+			method: http.MethodGet,
+			target: "/pets?limit=0&sort=color&status=a&status=b&status=c",
+			header: map[string]string{"X-Trace-Id": "abc"},
+			wantErr: []validator.FieldError{
+				{In: "query", Name: "limit", Message: "must be >= 1 but found 0"},
+				{In: "query", Name: "sort", Message: `value must be one of "name", "age"`},
+				{In: "query", Name: "status", Message: "maximum 2 items required, but found 3 items"},
+			},
+		},
+		{
+			name:   "query with wrong type",
+			method: http.MethodGet,
+			target: "/pets?limit=ten",
+			header: map[string]string{"X-Trace-Id": "abc"},
+			wantErr: []validator.FieldError{
+				{In: "query", Name: "limit", Message: "expected integer, but got string"},
+			},
+		},
+		{
+			name:   "literal path wins over template",
+			method: http.MethodGet,
+			target: "/pets/mine",
+		},
+		{
+			name:   "invalid path parameter",
+			method: http.MethodGet,
+			target: "/pets/0",
+			cookie: &http.Cookie{Name: "session", Value: "s1"},
+			wantErr: []validator.FieldError{
+				{In: "path", Name: "petId", Message: "must be >= 1 but found 0"},
+			},
+		},
+		{
+			name:   "valid JSON body",
+			method: http.MethodPost,
+			target: "/tenants/acme/pets",
+			body:   `{"name":"Rex","status":"available","tags":["a"]}`,
+			header: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			name:   "invalid JSON body",
+			method: http.MethodPost,
+			target: "/tenants/ACME/pets",
+			body:   `{"name":"R","status":"lost","tags":["a","b","c"]}`,
+			header: map[string]string{"Content-Type": "application/json; charset=utf-8"},
+			wantErr: []validator.FieldError{
+				{In: "path", Name: "tenant", Message: "does not match pattern '^[a-z]+$'"},
+				{In: "body", Pointer: "/name", Message: "length must be >= 2, but got 1"},
+				{In: "body", Pointer: "/status", Message: `value must be one of "available", "pending", "sold"`},
+				{In: "body", Pointer: "/tags", Message: "maximum 2 items required, but found 3 items"},
+			},
+		},
+		{
+			name:   "missing required body property",
+			method: http.MethodPost,
+			target: "/tenants/acme/pets",
+			body:   `{}`,
+			header: map[string]string{"Content-Type": "application/json"},
+			wantErr: []validator.FieldError{
+				{In: "body", Message: "missing properties: 'name'"},
+			},
+		},
+		{
+			name:   "malformed JSON body",
+			method: http.MethodPost,
+			target: "/tenants/acme/pets",
+			body:   `{"name":`,
+			header: map[string]string{"Content-Type": "application/json"},
+			wantErr: []validator.FieldError{
+				{In: "body", Message: "invalid JSON: unexpected EOF"},
+			},
+		},
+		{
+			name:   "unsupported content type",
+			method: http.MethodPost,
+			target: "/tenants/acme/pets",
+			body:   `name=Rex`,
+			header: map[string]string{"Content-Type": "text/plain"},
+			wantErr: []validator.FieldError{
+				{In: "body", Message: `unsupported content type "text/plain"`},
+			},
+		},
+		{
+			name:   "valid form body",
+			method: http.MethodPost,
+			target: "/login",
+			body:   `username=john&remember=true`,
+			header: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+		},
+		{
+			name:   "invalid form body",
+			method: http.MethodPost,
+			target: "/login",
+			body:   `remember=maybe`,
+			header: map[string]string{"Content-Type": "application/x-www-form-urlencoded"},
+			wantErr: []validator.FieldError{
+				{In: "body", Message: "missing properties: 'username'"},
+				{In: "body", Pointer: "/remember", Message: "expected boolean, but got string"},
+			},
+		},
+		{
+			name:   "undocumented route passes through",
+			method: http.MethodGet,
+			target: "/unknown",
+		},
+	}
+
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		v := validator.NewRequestValidator(newGenerator(version))
+		for _, tt := range tests {
+			t.Run(version+"/"+tt.name, func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+				for k, val := range tt.header {
+					req.Header.Set(k, val)
+				}
+				if tt.cookie != nil {
+					req.AddCookie(tt.cookie)
+				}
+
+				err := v.Validate(req)
+				if len(tt.wantErr) == 0 {
+					require.NoError(t, err)
+					return
+				}
+				var verr *validator.Error
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, http.StatusBadRequest, verr.Status)
+				assert.ElementsMatch(t, tt.wantErr, verr.Errors)
+			})
+		}
+	}
+}
+
+func TestRequestValidator_Middleware(t *testing.T) {
+	v := validator.NewRequestValidator(newGenerator("3.0.3"))
+	handler := v.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pet Pet
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&pet))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(pet.Name))
+	}))
+
+	t.Run("valid request reaches handler with body intact", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tenants/acme/pets", strings.NewReader(`{"name":"Rex"}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "Rex", rec.Body.String())
+	})
+
+	t.Run("invalid request is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tenants/acme/pets", strings.NewReader(`{"name":1}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var body validator.Error
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "invalid request for POST /tenants/{tenant}/pets", body.Message)
+		assert.Equal(t, []validator.FieldError{
+			{In: "body", Pointer: "/name", Message: "expected string, but got number"},
+		}, body.Errors)
+	})
+}
+
+func TestRequestValidator_ErrorHandler(t *testing.T) {
+	var handled error
+	v := validator.NewRequestValidator(newGenerator("3.1.0"),
+		validator.WithErrorHandler(func(w http.ResponseWriter, _ *http.Request, err error) {
+			handled = err
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}),
+	)
+	handler := v.Middleware(http.NotFoundHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets/abc", nil))
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	require.Error(t, handled)
+	assert.Contains(t, handled.Error(), "path petId: expected integer, but got string")
+}
+
+func TestRequestValidator_LateRoutes(t *testing.T) {
+	r := newGenerator("3.1.0")
+	v := validator.NewRequestValidator(r)
+	require.NoError(t, v.Validate(httptest.NewRequest(http.MethodGet, "/pets/mine", nil)))
+
+	type SearchRequest struct {
+		Limit int `query:"limit" minimum:"1"`
+	}
+	r.Get("/search", option.Request(new(SearchRequest)), option.Response(200, new([]Pet)))
+
+	err := v.Validate(httptest.NewRequest(http.MethodGet, "/search?limit=0", nil))
+	var verr *validator.Error
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "invalid request for GET /search", verr.Message)
+	assert.Equal(t, "limit", verr.Errors[0].Name)
+}
+
+func TestRequestValidator_InvalidSpec(t *testing.T) {
+	r := spec.NewRouter(option.WithOpenAPIVersion("2.0"))
+	v := validator.NewRequestValidator(r)
+	handler := v.Middleware(http.NotFoundHandler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pets", nil))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Contains(t, rec.Body.String(), "failed to build OpenAPI document")
+}
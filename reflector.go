@@ -10,6 +10,9 @@ import (
 	"github.com/oaswrap/spec/option"
 )
 
+// reflectPath is the temporary path under which callback operations and components are reflected.
+const reflectPath = "/x-oaswrap-callback"
+
 var (
 	re3  = regexp.MustCompile(`^3\.0\.\d(-.+)?$`)
 	re31 = regexp.MustCompile(`^3\.1\.\d+(-.+)?$`)
@@ -28,6 +31,41 @@ func newReflector(cfg *openapi.Config) reflector {
 	return newInvalidReflector(fmt.Errorf("unsupported OpenAPI version: %s", cfg.OpenAPIVersion))
 }
 
+// operationConfig returns the configuration of an operation with the given options.
+func operationConfig(opts []option.OperationOption) *option.OperationConfig {
+	cfg := &option.OperationConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// setOperationID sets the operation ID of an operation that does not set one explicitly,
+// using the configured function, then adds the prefix of its groups to the ID.
+func setOperationID(fn openapi.OperationIDFunc, cfg *option.OperationConfig, method, path string) {
+	if cfg.Hide {
+		return
+	}
+	if fn != nil && cfg.OperationID == "" {
+		cfg.OperationID = fn(method, path, cfg)
+	}
+	if cfg.OperationID != "" && cfg.OperationIDPrefix != "" {
+		cfg.OperationID = cfg.OperationIDPrefix + cfg.OperationID
+		cfg.OperationIDPrefix = "" // Added once, even if the ID is set again.
+	}
+}
+
+// transformSpec runs the transformers on the document and returns their errors.
+func transformSpec(doc any, transformers []openapi.SpecTransformer) []error {
+	var errs []error
+	for i, fn := range transformers {
+		if err := fn(doc); err != nil {
+			errs = append(errs, fmt.Errorf("spec transformer #%d: %w", i+1, err))
+		}
+	}
+	return errs
+}
+
 type invalidReflector struct {
 	spec   *noopSpec
 	errors *errs.SpecError
@@ -51,6 +89,14 @@ func (r *invalidReflector) Spec() spec {
 
 func (r *invalidReflector) Add(_, _ string, _ ...option.OperationOption) {}
 
+func (r *invalidReflector) AddWebhook(_, _ string, _ ...option.OperationOption) {}
+
+func (r *invalidReflector) Merge(_ []openapi.Fragment) {}
+
+func (r *invalidReflector) Transform(_ []openapi.SpecTransformer) {}
+
+func (r *invalidReflector) Check() {}
+
 func (r *invalidReflector) Validate() error {
 	if r.errors.HasErrors() {
 		return r.errors
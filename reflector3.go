@@ -1,7 +1,9 @@
 package spec
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/oaswrap/spec/internal/debuglog"
@@ -17,6 +19,9 @@ type reflector3 struct {
 	logger     *debuglog.Logger
 	errors     *errs.SpecError
 	pathParser openapi.PathParser
+	idFunc     openapi.OperationIDFunc
+	components *openapi.Components
+	routes     *routeTable
 }
 
 func newReflector3(cfg *openapi.Config, logger *debuglog.Logger) reflector {
@@ -99,11 +104,27 @@ func newReflector3(cfg *openapi.Config, logger *debuglog.Logger) reflector {
 		}
 	}
 
-	return &reflector3{
+	r := &reflector3{
 		reflector:  reflector,
 		logger:     logger,
 		errors:     &errs.SpecError{},
 		pathParser: cfg.PathParser,
+		idFunc:     cfg.OperationIDFunc,
+		components: &cfg.Components,
+		routes:     newRouteTable(cfg.OperationIDFunc),
+	}
+	r.checkSecuritySchemes(cfg)
+	r.addComponents(&cfg.Components)
+	return r
+}
+
+// checkSecuritySchemes reports the security schemes that are only available in OpenAPI 3.1.
+func (r *reflector3) checkSecuritySchemes(cfg *openapi.Config) {
+	for _, name := range sortedKeys(cfg.SecuritySchemes) {
+		if cfg.SecuritySchemes[name].MutualTLS != nil {
+			r.errors.Add(fmt.Errorf("security scheme %q: mutualTLS requires OpenAPI 3.1, but version %s is configured",
+				name, cfg.OpenAPIVersion))
+		}
 	}
 }
 
@@ -112,6 +133,7 @@ func (r *reflector3) Spec() spec {
 }
 
 func (r *reflector3) Add(method, path string, opts ...option.OperationOption) {
+	registered := path
 	if r.pathParser != nil {
 		parsedPath, err := r.pathParser.Parse(path)
 		if err != nil {
@@ -120,6 +142,16 @@ func (r *reflector3) Add(method, path string, opts ...option.OperationOption) {
 		}
 		path = parsedPath
 	}
+	cfg := operationConfig(opts)
+	if cfg.Hide {
+		r.logger.LogAction("skip operation", fmt.Sprintf("%s %s", strings.ToUpper(method), path))
+		return
+	}
+	if err := r.routes.add(method, registered, path, cfg); err != nil {
+		r.logger.LogOp(strings.ToUpper(method), path, "add operation", "conflicting route")
+		r.errors.Add(err)
+		return
+	}
 	op, err := r.newOperationContext(method, path)
 	if err != nil {
 		r.errors.Add(err)
@@ -129,7 +161,16 @@ func (r *reflector3) Add(method, path string, opts ...option.OperationOption) {
 	op.With(opts...)
 
 	method = strings.ToUpper(method)
+	setOperationID(r.idFunc, op.config(), method, path)
 
+	cfg = op.config()
+	if errs := checkPathParams(method, path, withGroupParams(cfg.GroupParams, cfg.Requests)); len(errs) > 0 {
+		r.logger.LogOp(method, path, "add operation", "inconsistent path parameters")
+		for _, err = range errs {
+			r.errors.Add(err)
+		}
+		return
+	}
 	if err = r.addOperation(op); err != nil {
 		r.logger.LogOp(method, path, "add operation", "failed")
 		r.errors.Add(err)
@@ -138,6 +179,60 @@ func (r *reflector3) Add(method, path string, opts ...option.OperationOption) {
 	r.logger.LogOp(method, path, "add operation", "successfully registered")
 }
 
+func (r *reflector3) AddWebhook(name, method string, _ ...option.OperationOption) {
+	r.logger.LogOp(strings.ToUpper(method), name, "add webhook", "failed")
+	r.errors.Add(fmt.Errorf("webhook %s %q requires OpenAPI 3.1, but version %s is configured",
+		strings.ToUpper(method), name, r.reflector.Spec.Openapi))
+}
+
+func (r *reflector3) Merge(fragments []openapi.Fragment) {
+	if len(fragments) == 0 {
+		return
+	}
+	markPublic3(r.reflector.Spec)
+	data, err := r.reflector.Spec.MarshalJSON()
+	if err != nil {
+		r.errors.Add(fmt.Errorf("failed to marshal spec for merging: %w", err))
+		return
+	}
+	merged, mergeErrs := mergeFragments(data, r.reflector.Spec.Openapi, fragments)
+	for _, err = range mergeErrs {
+		r.errors.Add(err)
+	}
+	if merged == nil {
+		return
+	}
+	var doc openapi3.Spec
+	if err = doc.UnmarshalJSON(merged); err != nil {
+		r.errors.Add(fmt.Errorf("failed to load merged spec: %w", err))
+		return
+	}
+	*r.reflector.Spec = doc
+	r.logger.LogAction("merge fragments", fmt.Sprintf("%d fragment(s)", len(fragments)))
+}
+
+func (r *reflector3) Transform(transformers []openapi.SpecTransformer) {
+	if len(transformers) == 0 {
+		return
+	}
+	for _, err := range transformSpec(r.reflector.Spec, transformers) {
+		r.errors.Add(err)
+	}
+	r.logger.LogAction("transform spec", fmt.Sprintf("%d transformer(s)", len(transformers)))
+}
+
+func (r *reflector3) Check() {
+	markPublic3(r.reflector.Spec)
+	data, err := r.reflector.Spec.MarshalJSON()
+	if err != nil {
+		r.errors.Add(fmt.Errorf("failed to marshal spec for checking: %w", err))
+		return
+	}
+	for _, err = range checkSpec(data) {
+		r.errors.Add(err)
+	}
+}
+
 func (r *reflector3) Validate() error {
 	if r.errors.HasErrors() {
 		return r.errors
@@ -146,11 +241,221 @@ func (r *reflector3) Validate() error {
 }
 
 func (r *reflector3) addOperation(oc operationContext) error {
-	openapiOC := oc.build()
-	if openapiOC == nil {
+	if oc.hidden() {
+		return nil
+	}
+	openapiOC, err := oc.build()
+	if err != nil {
+		return err
+	}
+	var existing map[string]bool
+	if oc.config().RequestBodyRef != "" {
+		existing = r.schemaNames()
+	}
+	if err = r.reflector.AddOperation(openapiOC); err != nil {
+		return err
+	}
+	if existing != nil {
+		if err = r.pruneSchemas(existing); err != nil {
+			return err
+		}
+	}
+	return r.addCallbacks(openapiOC.Method(), openapiOC.PathPattern(), oc.config().Callbacks)
+}
+
+// schemaNames returns the names of the schema components.
+func (r *reflector3) schemaNames() map[string]bool {
+	names := map[string]bool{}
+	if c := r.reflector.Spec.Components; c != nil && c.Schemas != nil {
+		for name := range c.Schemas.MapOfSchemaOrRefValues {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// pruneSchemas removes the schemas added since the existing ones that nothing references,
+// such as the schema of a request body replaced by a request body component.
+func (r *reflector3) pruneSchemas(existing map[string]bool) error {
+	data, err := r.reflector.Spec.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec for pruning schemas: %w", err)
+	}
+	unused, err := unreferencedSchemas(data, existing)
+	if err != nil || len(unused) == 0 {
+		return err
+	}
+	schemas := r.reflector.Spec.Components.Schemas
+	for _, name := range unused {
+		delete(schemas.MapOfSchemaOrRefValues, name)
+		r.logger.LogAction("remove unused schema", name)
+	}
+	if len(schemas.MapOfSchemaOrRefValues) == 0 {
+		r.reflector.Spec.Components.Schemas = nil
+	}
+	return nil
+}
+
+// addCallbacks reflects the callback operations and attaches them to the operation.
+func (r *reflector3) addCallbacks(method, path string, callbacks []option.CallbackConfig) error {
+	if len(callbacks) == 0 {
 		return nil
 	}
-	return r.reflector.AddOperation(openapiOC)
+	method = strings.ToLower(method)
+	pathItem := r.reflector.Spec.Paths.MapOfPathItemValues[path]
+	operation := pathItem.MapOfOperationValues[method]
+
+	for _, cb := range callbacks {
+		for _, cbOp := range cb.Operations {
+			op, ok, err := r.reflectCallbackOperation(cbOp)
+			if err != nil {
+				return fmt.Errorf("callback %q %s %s: %w", cb.Name, strings.ToUpper(cbOp.Method), cb.Expression, err)
+			}
+			if !ok {
+				continue
+			}
+			if err = addCallbackOperation3(&operation, cb, cbOp.Method, op); err != nil {
+				return err
+			}
+			r.logger.LogOp(strings.ToUpper(method), path, "add callback",
+				fmt.Sprintf("%s %s %s", cb.Name, strings.ToUpper(cbOp.Method), cb.Expression))
+		}
+	}
+	pathItem.MapOfOperationValues[method] = operation
+	return nil
+}
+
+func addCallbackOperation3(operation *openapi3.Operation, cb option.CallbackConfig, method string,
+	op openapi3.Operation) error {
+	callback := operation.Callbacks[cb.Name]
+	if callback.Callback == nil {
+		callback.Callback = &openapi3.Callback{}
+	}
+	item := callback.Callback.AdditionalProperties[cb.Expression]
+	method = strings.ToLower(method)
+	if _, exists := item.MapOfOperationValues[method]; exists {
+		return fmt.Errorf("callback %q %s %s already exists", cb.Name, strings.ToUpper(method), cb.Expression)
+	}
+	item.WithMapOfOperationValuesItem(method, op)
+	callback.Callback.WithAdditionalPropertiesItem(cb.Expression, item)
+	operation.WithCallbacksItem(cb.Name, callback)
+	return nil
+}
+
+// reflectCallbackOperation reflects a callback operation, if it is not hidden.
+func (r *reflector3) reflectCallbackOperation(cfg option.CallbackOperationConfig) (openapi3.Operation, bool, error) {
+	oc, err := r.newOperationContext(cfg.Method, reflectPath)
+	if err != nil {
+		return openapi3.Operation{}, false, err
+	}
+	if oc.With(cfg.Options...).hidden() {
+		return openapi3.Operation{}, false, nil
+	}
+	op, err := r.reflectOperation(oc)
+	return op, err == nil, err
+}
+
+// reflectOperation reflects an operation under a temporary path, so it shares the
+// components of the spec, and removes it from the paths afterwards.
+func (r *reflector3) reflectOperation(oc operationContext) (openapi3.Operation, error) {
+	openapiOC, err := oc.build()
+	if err != nil {
+		return openapi3.Operation{}, err
+	}
+	defer func() { delete(r.reflector.Spec.Paths.MapOfPathItemValues, reflectPath) }()
+	if err = r.reflector.AddOperation(openapiOC); err != nil {
+		return openapi3.Operation{}, err
+	}
+	paths := r.reflector.Spec.Paths.MapOfPathItemValues
+	return paths[reflectPath].MapOfOperationValues[openapiOC.Method()], nil
+}
+
+// addComponents reflects the reusable components of the configuration.
+func (r *reflector3) addComponents(c *openapi.Components) {
+	for _, name := range sortedKeys(c.Responses) {
+		r.addComponentError("response", name, r.addResponseComponent(name, c.Responses[name]))
+	}
+	for _, name := range sortedKeys(c.Parameters) {
+		r.addComponentError("parameter", name, r.addParameterComponent(name, c.Parameters[name]))
+	}
+	for _, name := range sortedKeys(c.RequestBodies) {
+		r.addComponentError("request body", name, r.addRequestBodyComponent(name, c.RequestBodies[name]))
+	}
+	for _, name := range sortedKeys(c.Headers) {
+		r.addComponentError("header", name, r.addHeaderComponent(name, c.Headers[name]))
+	}
+}
+
+func (r *reflector3) addResponseComponent(name string, units []*openapi.ContentUnit) error {
+	status, err := componentResponseStatus(units)
+	if err != nil {
+		return err
+	}
+	op, err := r.reflectComponent(http.MethodGet, func(cfg *option.OperationConfig) {
+		cfg.Responses = units
+	})
+	if err != nil {
+		return err
+	}
+	resp := op.Responses.MapOfResponseOrRefValues[status]
+	if status == "default" {
+		resp = *op.Responses.Default
+	}
+	r.reflector.SpecEns().ComponentsEns().ResponsesEns().WithMapOfResponseOrRefValuesItem(name, resp)
+	return nil
+}
+
+func (r *reflector3) addParameterComponent(name string, structure any) error {
+	op, err := r.reflectComponent(http.MethodGet, option.Request(structure))
+	if err != nil {
+		return err
+	}
+	if len(op.Parameters) != 1 {
+		return fmt.Errorf("structure must declare exactly one parameter, found %d", len(op.Parameters))
+	}
+	r.reflector.SpecEns().ComponentsEns().ParametersEns().WithMapOfParameterOrRefValuesItem(name, op.Parameters[0])
+	return nil
+}
+
+func (r *reflector3) addRequestBodyComponent(name string, units []*openapi.ContentUnit) error {
+	op, err := r.reflectComponent(http.MethodPost, func(cfg *option.OperationConfig) {
+		cfg.Requests = units
+	})
+	if err != nil {
+		return err
+	}
+	if op.RequestBody == nil {
+		return errors.New("structure has no body fields")
+	}
+	r.reflector.SpecEns().ComponentsEns().RequestBodiesEns().WithMapOfRequestBodyOrRefValuesItem(name, *op.RequestBody)
+	return nil
+}
+
+func (r *reflector3) addHeaderComponent(name string, header openapi.Header) error {
+	headers, err := reflectResponseHeaders(r.reflector.JSONSchemaReflector(),
+		&openapi.ContentUnit{Headers: []openapi.Header{header}})
+	if err != nil {
+		return err
+	}
+	r.reflector.SpecEns().ComponentsEns().HeadersEns().WithMapOfHeaderOrRefValuesItem(name, headers[0].oas3())
+	return nil
+}
+
+// reflectComponent reflects the structures of a component as those of a temporary operation.
+func (r *reflector3) reflectComponent(method string, opt option.OperationOption) (openapi3.Operation, error) {
+	oc, err := r.newOperationContext(method, reflectPath)
+	if err != nil {
+		return openapi3.Operation{}, err
+	}
+	return r.reflectOperation(oc.With(opt))
+}
+
+func (r *reflector3) addComponentError(kind, name string, err error) {
+	if err != nil {
+		r.errors.Add(fmt.Errorf("%s component %q: %w", kind, name, err))
+		return
+	}
+	r.logger.LogAction("add "+kind+" component", name)
 }
 
 func (r *reflector3) newOperationContext(method, path string) (operationContext, error) {
@@ -159,8 +464,10 @@ func (r *reflector3) newOperationContext(method, path string) (operationContext,
 		return nil, err
 	}
 	return &operationContextImpl{
-		op:     op,
-		logger: r.logger,
-		cfg:    &option.OperationConfig{},
+		op:         op,
+		logger:     r.logger,
+		cfg:        &option.OperationConfig{},
+		schemas:    r.reflector.JSONSchemaReflector(),
+		components: r.components,
 	}, nil
 }
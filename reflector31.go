@@ -1,7 +1,9 @@
 package spec
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/oaswrap/spec/internal/debuglog"
@@ -16,7 +18,10 @@ type reflector31 struct {
 	reflector  *openapi31.Reflector
 	logger     *debuglog.Logger
 	pathParser openapi.PathParser
+	idFunc     openapi.OperationIDFunc
 	errors     *errs.SpecError
+	components *openapi.Components
+	routes     *routeTable
 }
 
 func newReflector31(cfg *openapi.Config, logger *debuglog.Logger) reflector {
@@ -96,15 +101,21 @@ func newReflector31(cfg *openapi.Config, logger *debuglog.Logger) reflector {
 		}
 	}
 
-	return &reflector31{
+	r := &reflector31{
 		reflector:  reflector,
 		logger:     logger,
 		errors:     &errs.SpecError{},
 		pathParser: cfg.PathParser,
+		idFunc:     cfg.OperationIDFunc,
+		components: &cfg.Components,
+		routes:     newRouteTable(cfg.OperationIDFunc),
 	}
+	r.addComponents(&cfg.Components)
+	return r
 }
 
 func (r *reflector31) Add(method, path string, opts ...option.OperationOption) {
+	registered := path
 	if r.pathParser != nil {
 		parsedPath, err := r.pathParser.Parse(path)
 		if err != nil {
@@ -113,6 +124,17 @@ func (r *reflector31) Add(method, path string, opts ...option.OperationOption) {
 		}
 		path = parsedPath
 	}
+	cfg := operationConfig(opts)
+	if cfg.Hide {
+		r.reflector.SpecEns().PathsEns() // The paths object is documented even if every operation is hidden.
+		r.logger.LogAction("skip operation", fmt.Sprintf("%s %s", strings.ToUpper(method), path))
+		return
+	}
+	if err := r.routes.add(method, registered, path, cfg); err != nil {
+		r.logger.LogOp(strings.ToUpper(method), path, "add operation", "conflicting route")
+		r.errors.Add(err)
+		return
+	}
 	op, err := r.newOperationContext(method, path)
 	if err != nil {
 		r.errors.Add(err)
@@ -122,7 +144,16 @@ func (r *reflector31) Add(method, path string, opts ...option.OperationOption) {
 	op.With(opts...)
 
 	method = strings.ToUpper(method)
+	setOperationID(r.idFunc, op.config(), method, path)
 
+	cfg = op.config()
+	if errs := checkPathParams(method, path, withGroupParams(cfg.GroupParams, cfg.Requests)); len(errs) > 0 {
+		r.logger.LogOp(method, path, "add operation", "inconsistent path parameters")
+		for _, err = range errs {
+			r.errors.Add(err)
+		}
+		return
+	}
 	if err = r.addOperation(op); err != nil {
 		r.logger.LogOp(method, path, "add operation", "failed")
 		r.errors.Add(err)
@@ -135,6 +166,110 @@ func (r *reflector31) Spec() spec {
 	return r.reflector.Spec
 }
 
+func (r *reflector31) AddWebhook(name, method string, opts ...option.OperationOption) {
+	op, err := r.newOperationContext(method, name)
+	if err != nil {
+		r.errors.Add(fmt.Errorf("webhook %q: %w", name, err))
+		return
+	}
+
+	op.With(opts...)
+
+	method = strings.ToUpper(method)
+	setOperationID(r.idFunc, op.config(), method, name)
+
+	if err = r.addWebhook(name, method, op); err != nil {
+		r.logger.LogOp(method, name, "add webhook", "failed")
+		r.errors.Add(err)
+		return
+	}
+	r.logger.LogOp(method, name, "add webhook", "successfully registered")
+}
+
+// addWebhook adds the webhook operation, keeping the other methods already
+// registered under the same name.
+func (r *reflector31) addWebhook(name, method string, oc operationContext) error {
+	if oc.hidden() {
+		return nil
+	}
+	openapiOC, err := oc.build()
+	if err != nil {
+		return err
+	}
+
+	spec := r.reflector.SpecEns()
+	existing, found := spec.Webhooks[name]
+	delete(spec.Webhooks, name)
+	if err = r.reflector.AddWebhook(openapiOC); err != nil {
+		if found {
+			spec.Webhooks[name] = existing
+		}
+		return err
+	}
+	if !found || existing.PathItem == nil {
+		return nil
+	}
+
+	added := spec.Webhooks[name]
+	spec.Webhooks[name] = existing
+	if op, _ := existing.PathItem.Operation(method); op != nil {
+		return fmt.Errorf("webhook already exists: %s %s", method, name)
+	}
+	op, err := added.PathItem.Operation(method)
+	if err != nil {
+		return err
+	}
+	return existing.PathItem.SetOperation(method, op)
+}
+
+func (r *reflector31) Merge(fragments []openapi.Fragment) {
+	if len(fragments) == 0 {
+		return
+	}
+	markPublic31(r.reflector.Spec)
+	data, err := r.reflector.Spec.MarshalJSON()
+	if err != nil {
+		r.errors.Add(fmt.Errorf("failed to marshal spec for merging: %w", err))
+		return
+	}
+	merged, mergeErrs := mergeFragments(data, r.reflector.Spec.Openapi, fragments)
+	for _, err = range mergeErrs {
+		r.errors.Add(err)
+	}
+	if merged == nil {
+		return
+	}
+	var doc openapi31.Spec
+	if err = doc.UnmarshalJSON(merged); err != nil {
+		r.errors.Add(fmt.Errorf("failed to load merged spec: %w", err))
+		return
+	}
+	*r.reflector.Spec = doc
+	r.logger.LogAction("merge fragments", fmt.Sprintf("%d fragment(s)", len(fragments)))
+}
+
+func (r *reflector31) Transform(transformers []openapi.SpecTransformer) {
+	if len(transformers) == 0 {
+		return
+	}
+	for _, err := range transformSpec(r.reflector.Spec, transformers) {
+		r.errors.Add(err)
+	}
+	r.logger.LogAction("transform spec", fmt.Sprintf("%d transformer(s)", len(transformers)))
+}
+
+func (r *reflector31) Check() {
+	markPublic31(r.reflector.Spec)
+	data, err := r.reflector.Spec.MarshalJSON()
+	if err != nil {
+		r.errors.Add(fmt.Errorf("failed to marshal spec for checking: %w", err))
+		return
+	}
+	for _, err = range checkSpec(data) {
+		r.errors.Add(err)
+	}
+}
+
 func (r *reflector31) Validate() error {
 	if r.errors.HasErrors() {
 		return r.errors
@@ -143,11 +278,235 @@ func (r *reflector31) Validate() error {
 }
 
 func (r *reflector31) addOperation(oc operationContext) error {
-	openapiOC := oc.build()
-	if openapiOC == nil {
+	if oc.hidden() {
 		return nil
 	}
-	return r.reflector.AddOperation(openapiOC)
+	openapiOC, err := oc.build()
+	if err != nil {
+		return err
+	}
+	var existing map[string]bool
+	if oc.config().RequestBodyRef != "" {
+		existing = r.schemaNames()
+	}
+	if err = r.reflector.AddOperation(openapiOC); err != nil {
+		return err
+	}
+	if existing != nil {
+		if err = r.pruneSchemas(existing); err != nil {
+			return err
+		}
+	}
+	return r.addCallbacks(openapiOC.Method(), openapiOC.PathPattern(), oc.config().Callbacks)
+}
+
+// schemaNames returns the names of the schema components.
+func (r *reflector31) schemaNames() map[string]bool {
+	names := map[string]bool{}
+	if c := r.reflector.Spec.Components; c != nil {
+		for name := range c.Schemas {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// pruneSchemas removes the schemas added since the existing ones that nothing references,
+// such as the schema of a request body replaced by a request body component.
+func (r *reflector31) pruneSchemas(existing map[string]bool) error {
+	data, err := r.reflector.Spec.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec for pruning schemas: %w", err)
+	}
+	unused, err := unreferencedSchemas(data, existing)
+	if err != nil || len(unused) == 0 {
+		return err
+	}
+	components := r.reflector.Spec.Components
+	for _, name := range unused {
+		delete(components.Schemas, name)
+		r.logger.LogAction("remove unused schema", name)
+	}
+	if len(components.Schemas) == 0 {
+		components.Schemas = nil
+	}
+	return nil
+}
+
+// addCallbacks reflects the callback operations and attaches them to the operation.
+func (r *reflector31) addCallbacks(method, path string, callbacks []option.CallbackConfig) error {
+	if len(callbacks) == 0 {
+		return nil
+	}
+	pathItem := r.reflector.Spec.Paths.MapOfPathItemValues[path]
+	operation, err := pathItem.Operation(method)
+	if err != nil || operation == nil {
+		return err
+	}
+
+	for _, cb := range callbacks {
+		for _, cbOp := range cb.Operations {
+			op, ok, opErr := r.reflectCallbackOperation(cbOp)
+			if opErr != nil {
+				return fmt.Errorf("callback %q %s %s: %w", cb.Name, strings.ToUpper(cbOp.Method), cb.Expression, opErr)
+			}
+			if !ok {
+				continue
+			}
+			if err = addCallbackOperation31(operation, cb, cbOp.Method, op); err != nil {
+				return err
+			}
+			r.logger.LogOp(strings.ToUpper(method), path, "add callback",
+				fmt.Sprintf("%s %s %s", cb.Name, strings.ToUpper(cbOp.Method), cb.Expression))
+		}
+	}
+	return nil
+}
+
+func addCallbackOperation31(operation *openapi31.Operation, cb option.CallbackConfig, method string,
+	op openapi31.Operation) error {
+	callback := operation.Callbacks[cb.Name]
+	if callback.Callbacks == nil {
+		callback.Callbacks = &openapi31.Callbacks{}
+	}
+	item := callback.Callbacks.AdditionalProperties[cb.Expression]
+	if item.PathItem == nil {
+		item.PathItem = &openapi31.PathItem{}
+	}
+	if existing, _ := item.PathItem.Operation(method); existing != nil {
+		return fmt.Errorf("callback %q %s %s already exists", cb.Name, strings.ToUpper(method), cb.Expression)
+	}
+	if err := item.PathItem.SetOperation(method, &op); err != nil {
+		return err
+	}
+	callback.Callbacks.WithAdditionalPropertiesItem(cb.Expression, item)
+	operation.WithCallbacksItem(cb.Name, callback)
+	return nil
+}
+
+// reflectCallbackOperation reflects a callback operation, if it is not hidden.
+func (r *reflector31) reflectCallbackOperation(cfg option.CallbackOperationConfig) (openapi31.Operation, bool, error) {
+	oc, err := r.newOperationContext(cfg.Method, reflectPath)
+	if err != nil {
+		return openapi31.Operation{}, false, err
+	}
+	if oc.With(cfg.Options...).hidden() {
+		return openapi31.Operation{}, false, nil
+	}
+	op, err := r.reflectOperation(oc)
+	return op, err == nil, err
+}
+
+// reflectOperation reflects an operation under a temporary path, so it shares the
+// components of the spec, and removes it from the paths afterwards.
+func (r *reflector31) reflectOperation(oc operationContext) (openapi31.Operation, error) {
+	openapiOC, err := oc.build()
+	if err != nil {
+		return openapi31.Operation{}, err
+	}
+	defer func() { delete(r.reflector.Spec.Paths.MapOfPathItemValues, reflectPath) }()
+	if err = r.reflector.AddOperation(openapiOC); err != nil {
+		return openapi31.Operation{}, err
+	}
+	paths := r.reflector.Spec.Paths.MapOfPathItemValues
+	item := paths[reflectPath]
+	op, err := item.Operation(openapiOC.Method())
+	if err != nil {
+		return openapi31.Operation{}, err
+	}
+	if op == nil {
+		return openapi31.Operation{}, fmt.Errorf("operation %s was not reflected", strings.ToUpper(openapiOC.Method()))
+	}
+	return *op, nil
+}
+
+// addComponents reflects the reusable components of the configuration.
+func (r *reflector31) addComponents(c *openapi.Components) {
+	for _, name := range sortedKeys(c.Responses) {
+		r.addComponentError("response", name, r.addResponseComponent(name, c.Responses[name]))
+	}
+	for _, name := range sortedKeys(c.Parameters) {
+		r.addComponentError("parameter", name, r.addParameterComponent(name, c.Parameters[name]))
+	}
+	for _, name := range sortedKeys(c.RequestBodies) {
+		r.addComponentError("request body", name, r.addRequestBodyComponent(name, c.RequestBodies[name]))
+	}
+	for _, name := range sortedKeys(c.Headers) {
+		r.addComponentError("header", name, r.addHeaderComponent(name, c.Headers[name]))
+	}
+}
+
+func (r *reflector31) addResponseComponent(name string, units []*openapi.ContentUnit) error {
+	status, err := componentResponseStatus(units)
+	if err != nil {
+		return err
+	}
+	op, err := r.reflectComponent(http.MethodGet, func(cfg *option.OperationConfig) {
+		cfg.Responses = units
+	})
+	if err != nil {
+		return err
+	}
+	responses := op.ResponsesEns()
+	resp := responses.MapOfResponseOrReferenceValues[status]
+	if status == "default" {
+		resp = *responses.Default
+	}
+	r.reflector.SpecEns().ComponentsEns().WithResponsesItem(name, resp)
+	return nil
+}
+
+func (r *reflector31) addParameterComponent(name string, structure any) error {
+	op, err := r.reflectComponent(http.MethodGet, option.Request(structure))
+	if err != nil {
+		return err
+	}
+	if len(op.Parameters) != 1 {
+		return fmt.Errorf("structure must declare exactly one parameter, found %d", len(op.Parameters))
+	}
+	r.reflector.SpecEns().ComponentsEns().WithParametersItem(name, op.Parameters[0])
+	return nil
+}
+
+func (r *reflector31) addRequestBodyComponent(name string, units []*openapi.ContentUnit) error {
+	op, err := r.reflectComponent(http.MethodPost, func(cfg *option.OperationConfig) {
+		cfg.Requests = units
+	})
+	if err != nil {
+		return err
+	}
+	if op.RequestBody == nil {
+		return errors.New("structure has no body fields")
+	}
+	r.reflector.SpecEns().ComponentsEns().WithRequestBodiesItem(name, *op.RequestBody)
+	return nil
+}
+
+func (r *reflector31) addHeaderComponent(name string, header openapi.Header) error {
+	headers, err := reflectResponseHeaders(r.reflector.JSONSchemaReflector(),
+		&openapi.ContentUnit{Headers: []openapi.Header{header}})
+	if err != nil {
+		return err
+	}
+	r.reflector.SpecEns().ComponentsEns().WithHeadersItem(name, headers[0].oas31())
+	return nil
+}
+
+// reflectComponent reflects the structures of a component as those of a temporary operation.
+func (r *reflector31) reflectComponent(method string, opt option.OperationOption) (openapi31.Operation, error) {
+	oc, err := r.newOperationContext(method, reflectPath)
+	if err != nil {
+		return openapi31.Operation{}, err
+	}
+	return r.reflectOperation(oc.With(opt))
+}
+
+func (r *reflector31) addComponentError(kind, name string, err error) {
+	if err != nil {
+		r.errors.Add(fmt.Errorf("%s component %q: %w", kind, name, err))
+		return
+	}
+	r.logger.LogAction("add "+kind+" component", name)
 }
 
 func (r *reflector31) newOperationContext(method, path string) (operationContext, error) {
@@ -156,8 +515,10 @@ func (r *reflector31) newOperationContext(method, path string) (operationContext
 		return nil, err
 	}
 	return &operationContextImpl{
-		op:     op,
-		logger: r.logger,
-		cfg:    &option.OperationConfig{},
+		op:         op,
+		logger:     r.logger,
+		cfg:        &option.OperationConfig{},
+		schemas:    r.reflector.JSONSchemaReflector(),
+		components: r.components,
 	}, nil
 }
@@ -17,15 +17,64 @@ import (
 
 // generator implements the Generator interface for creating OpenAPI specifications.
 type generator struct {
-	reflector reflector
-	spec      spec
-	cfg       *openapi.Config
+	cfg *openapi.Config
+	reg *registry
 
 	prefix string
 	groups []*generator
 	routes []*route
 	opts   []option.GroupOption
-	once   sync.Once
+
+	webhooks []*webhook
+}
+
+// registry holds the state shared by a generator and its groups.
+//
+// The spec is built when it is first used and kept until routes change, so routes
+// registered later are included the next time the spec is used.
+type registry struct {
+	mu         sync.Mutex
+	root       *generator
+	generation uint64    // Number of changes made to the routes.
+	reflector  reflector // Reflector of the spec built for the current generation; nil until it is built.
+}
+
+// change applies a change to the routes and discards the built spec.
+func (reg *registry) change(fn func()) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	fn()
+	reg.generation++
+	reg.reflector = nil
+}
+
+// built returns the reflector of the spec, building it if routes changed since the
+// spec was last built.
+//
+// The spec is built from a copy of the routes without holding the lock, so the options,
+// path parser and transformers that run during the build may use the generator. A spec
+// built while routes changed is returned but not kept.
+func (reg *registry) built() reflector {
+	reg.mu.Lock()
+	if built := reg.reflector; built != nil {
+		reg.mu.Unlock()
+		return built
+	}
+	root, generation := reg.root.clone(), reg.generation
+	reg.mu.Unlock()
+
+	reflector := root.buildSpec()
+
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	if reg.generation != generation {
+		return reflector
+	}
+	if reg.reflector == nil {
+		reg.reflector = reflector
+	}
+	return reg.reflector
 }
 
 var _ Generator = (*generator)(nil)
@@ -45,13 +94,10 @@ func NewRouter(opts ...option.OpenAPIOption) Generator {
 func NewGenerator(opts ...option.OpenAPIOption) Generator {
 	cfg := option.WithOpenAPIConfig(opts...)
 
-	reflector := newReflector(cfg)
-
 	generator := &generator{
-		reflector: reflector,
-		spec:      reflector.Spec(),
-		cfg:       cfg,
+		cfg: cfg,
 	}
+	generator.reg = &registry{root: generator}
 
 	return generator
 }
@@ -107,23 +153,40 @@ func (g *generator) Add(method, path string, opts ...option.OperationOption) Rou
 		path = util.JoinPath(g.prefix, path)
 	}
 	route := &route{
+		reg:    g.reg,
 		prefix: g.prefix,
 		method: method,
 		path:   path,
 		opts:   opts,
 	}
-	g.routes = append(g.routes, route)
+	g.reg.change(func() {
+		g.routes = append(g.routes, route)
+	})
 
 	return route
 }
 
+// Webhook registers an outgoing webhook with the given name, HTTP method, and options.
+func (g *generator) Webhook(name, method string, opts ...option.OperationOption) {
+	g.reg.change(func() {
+		g.webhooks = append(g.webhooks, &webhook{
+			name:   name,
+			method: method,
+			opts:   opts,
+		})
+	})
+}
+
 // NewRoute creates a new route with the given options.
 func (g *generator) NewRoute(opts ...option.OperationOption) Route {
 	route := &route{
+		reg:    g.reg,
 		prefix: g.prefix,
 		opts:   opts,
 	}
-	g.routes = append(g.routes, route)
+	g.reg.change(func() {
+		g.routes = append(g.routes, route)
+	})
 
 	return route
 }
@@ -138,35 +201,41 @@ func (g *generator) Route(pattern string, fn func(router Router), opts ...option
 // Group creates a new sub-router with the given path prefix and group options.
 func (g *generator) Group(pattern string, opts ...option.GroupOption) Router {
 	group := &generator{
-		prefix:    util.JoinPath(g.prefix, pattern),
-		reflector: g.reflector,
-		cfg:       g.cfg,
-		opts:      opts,
+		prefix: util.JoinPath(g.prefix, pattern),
+		cfg:    g.cfg,
+		reg:    g.reg,
+		opts:   opts,
 	}
-	g.groups = append(g.groups, group)
+	g.reg.change(func() {
+		g.groups = append(g.groups, group)
+	})
 	return group
 }
 
 // With applies one or more group options to the router.
 func (g *generator) With(opts ...option.GroupOption) Router {
-	g.opts = append(g.opts, opts...)
+	g.reg.change(func() {
+		g.opts = append(g.opts, opts...)
+	})
 	return g
 }
 
 // MarshalYAML and MarshalJSON implement the YAML and JSON serialization for the OpenAPI specification.
 func (g *generator) MarshalYAML() ([]byte, error) {
-	if err := g.Validate(); err != nil {
+	reflector := g.reg.built()
+	if err := reflector.Validate(); err != nil {
 		return nil, err
 	}
-	return g.spec.MarshalYAML()
+	return reflector.Spec().MarshalYAML()
 }
 
 // MarshalJSON implements the JSON serialization for the OpenAPI specification.
 func (g *generator) MarshalJSON() ([]byte, error) {
-	if err := g.Validate(); err != nil {
+	reflector := g.reg.built()
+	if err := reflector.Validate(); err != nil {
 		return nil, err
 	}
-	schema, err := g.spec.MarshalJSON()
+	schema, err := reflector.Spec().MarshalJSON()
 	if err != nil {
 		return nil, err
 	}
@@ -214,85 +283,208 @@ func (g *generator) WriteSchemaTo(path string) error {
 }
 
 // Validate checks whether the OpenAPI specification is valid.
-func (g *generator) Validate() error {
-	g.buildOnce()
+func (g *generator) Validate(opts ...ValidateOption) error {
+	cfg := &validateConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	reflector := g.reg.built()
+	if err := reflector.Validate(); err != nil || !cfg.schemaValidation {
+		return err
+	}
+	return validateSchema(reflector.Spec())
+}
 
-	return g.reflector.Validate()
+// Document returns the built document, or nil if the OpenAPI version is not supported.
+//
+// The document is checked when it is built, so later changes to it are not validated.
+func (g *generator) Document() any {
+	doc := g.reg.built().Spec()
+	if _, ok := doc.(*noopSpec); ok {
+		return nil
+	}
+	return doc
 }
 
-func (g *generator) buildOnce() {
-	g.once.Do(func() {
-		for _, r := range g.build() {
-			g.reflector.Add(r.method, r.path, r.opts...)
+// Operations returns the registered operations in registration order, with the options
+// of their groups applied.
+//
+// Each call returns new configurations, so changing them does not affect the generator.
+func (g *generator) Operations() []Operation {
+	g.reg.mu.Lock()
+	snapshot := g.clone()
+	g.reg.mu.Unlock()
+
+	routes := snapshot.build(nil)
+
+	ops := make([]Operation, 0, len(routes))
+	for _, r := range routes {
+		path := r.path
+		if g.cfg.PathParser != nil {
+			if parsed, err := g.cfg.PathParser.Parse(path); err == nil {
+				path = parsed
+			}
 		}
-	})
+		cfg := operationConfig(r.opts)
+		method := strings.ToUpper(r.method)
+		setOperationID(g.cfg.OperationIDFunc, cfg, method, path)
+		ops = append(ops, Operation{
+			Method: method,
+			Path:   path,
+			Config: cfg,
+			Hidden: cfg.Hide,
+		})
+	}
+	return ops
+}
+
+// buildSpec builds the spec of the generator with a new reflector.
+func (g *generator) buildSpec() reflector {
+	reflector := newReflector(g.cfg)
+	for _, r := range g.build(nil) {
+		if r.groupHidden {
+			continue
+		}
+		reflector.Add(r.method, r.path, r.opts...)
+	}
+	for _, w := range g.webhooks {
+		reflector.AddWebhook(w.name, w.method, w.opts...)
+	}
+	reflector.Merge(g.cfg.Fragments)
+	reflector.Transform(g.cfg.SpecTransformers)
+	reflector.Check()
+	return reflector
 }
 
-func (g *generator) build() []*route {
+// clone returns a copy of the generator with its groups, routes and webhooks, from which
+// the spec can be built while routes are registered. The caller holds the registry lock.
+func (g *generator) clone() *generator {
+	c := &generator{
+		cfg:    g.cfg,
+		prefix: g.prefix,
+		opts:   slices.Clone(g.opts),
+	}
+	for _, r := range g.routes {
+		c.routes = append(c.routes, &route{
+			prefix: r.prefix,
+			method: r.method,
+			path:   r.path,
+			opts:   slices.Clone(r.opts),
+		})
+	}
+	for _, w := range g.webhooks {
+		c.webhooks = append(c.webhooks, &webhook{
+			name:   w.name,
+			method: w.method,
+			opts:   slices.Clone(w.opts),
+		})
+	}
+	for _, group := range g.groups {
+		c.groups = append(c.groups, group.clone())
+	}
+	return c
+}
+
+// build returns the complete routes of the generator and its groups, with the options
+// of the groups applied. Routes hidden by a group are included and marked as such.
+func (g *generator) build(parentOpts []option.GroupOption) []*route {
+	groupOpts := make([]option.GroupOption, 0, len(parentOpts)+len(g.opts))
+	groupOpts = append(groupOpts, parentOpts...)
+	groupOpts = append(groupOpts, g.opts...)
+
 	var routes []*route
 	for _, r := range g.routes {
 		if r.method == "" || r.path == "" {
 			continue // Skip incomplete routes
 		}
-
-		opts, ok := g.buildRouteGroupOpts()
-		if !ok {
-			continue
-		}
-		if len(r.opts) > 0 {
-			r.opts = append(r.opts, opts...)
-		}
-		routes = append(routes, r)
+		opts, hidden := buildRouteOpts(groupOpts, r.opts)
+		routes = append(routes, &route{
+			prefix:      r.prefix,
+			method:      r.method,
+			path:        r.path,
+			opts:        opts,
+			groupHidden: hidden,
+		})
 	}
 
 	for _, group := range g.groups {
-		group.opts = append(g.opts, group.opts...)
-		routes = append(routes, group.build()...)
+		routes = append(routes, group.build(groupOpts)...)
 	}
 	return routes
 }
 
-func (g *generator) buildRouteGroupOpts() ([]option.OperationOption, bool) {
-	var opts []option.OperationOption
-	if len(g.opts) > 0 {
-		cfg := &option.GroupConfig{}
-		for _, opt := range g.opts {
-			opt(cfg)
-		}
-		if cfg.Hide {
-			return nil, false
-		}
-		if cfg.Deprecated {
-			opts = append(opts, option.Deprecated(true))
-		}
-		if len(cfg.Tags) > 0 {
-			opts = append(opts, option.Tags(cfg.Tags...))
-		}
-		if len(cfg.Security) > 0 {
-			for _, sec := range cfg.Security {
-				opts = append(opts, option.Security(sec.Name, sec.Scopes...))
+// buildRouteOpts returns the options of a route combined with the options of its groups,
+// and whether one of the groups hides the route.
+//
+// Security and operation options of the groups come first, so the route can override them,
+// while tags and prefixes are added on top of the route options.
+func buildRouteOpts(groupOpts []option.GroupOption, routeOpts []option.OperationOption) (
+	[]option.OperationOption, bool,
+) {
+	if len(groupOpts) == 0 {
+		return routeOpts, false
+	}
+	cfg := &option.GroupConfig{}
+	for _, opt := range groupOpts {
+		opt(cfg)
+	}
+	opts := make([]option.OperationOption, 0, len(cfg.Options)+len(routeOpts)+1)
+	if len(cfg.Security) > 0 {
+		opts = append(opts, func(oc *option.OperationConfig) {
+			oc.Security = append(oc.Security, cfg.Security...)
+		})
+	}
+	opts = append(opts, cfg.Options...)
+	opts = append(opts, routeOpts...)
+	if cfg.Deprecated {
+		opts = append(opts, option.Deprecated(true))
+	}
+	if len(cfg.Tags) > 0 {
+		opts = append(opts, option.Tags(cfg.Tags...))
+	}
+	if cfg.SummaryPrefix != "" || cfg.OperationIDPrefix != "" {
+		opts = append(opts, func(oc *option.OperationConfig) {
+			if oc.Summary != "" {
+				oc.Summary = cfg.SummaryPrefix + oc.Summary
 			}
-		}
+			oc.OperationIDPrefix = cfg.OperationIDPrefix + oc.OperationIDPrefix
+		})
 	}
-	return opts, true
+	if cfg.Hide {
+		opts = append(opts, option.Hidden(true))
+	}
+	return opts, cfg.Hide
+}
+
+type webhook struct {
+	name   string
+	method string
+	opts   []option.OperationOption
 }
 
 type route struct {
+	reg    *registry
 	prefix string // Path prefix for the route
 	method string
 	path   string
 	opts   []option.OperationOption
+
+	groupHidden bool // Whether a group of the route hides it.
 }
 
 var _ Route = (*route)(nil)
 
 func (r *route) With(opts ...option.OperationOption) Route {
-	r.opts = append(r.opts, opts...)
+	r.reg.change(func() {
+		r.opts = append(r.opts, opts...)
+	})
 	return r
 }
 
 func (r *route) Method(method string) Route {
-	r.method = method
+	r.reg.change(func() {
+		r.method = method
+	})
 	return r
 }
 
@@ -300,6 +492,8 @@ func (r *route) Path(path string) Route {
 	if r.prefix != "" {
 		path = util.JoinPath(r.prefix, path)
 	}
-	r.path = path
+	r.reg.change(func() {
+		r.path = path
+	})
 	return r
 }
@@ -2,6 +2,7 @@ package spec_test
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -9,16 +10,21 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/oaswrap/spec"
 	"github.com/oaswrap/spec/openapi"
 	"github.com/oaswrap/spec/option"
 	"github.com/oaswrap/spec/pkg/dto"
+	"github.com/oaswrap/spec/pkg/parser"
 	"github.com/oaswrap/spec/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
 )
 
 //nolint:gochecknoglobals // test flag for golden file updates
@@ -94,6 +100,97 @@ type User struct {
 	UpdatedAt NullTime   `json:"updated_at"`
 }
 
+const legacyFragment = `
+paths:
+  /users:
+    summary: Users
+  /legacy/reports:
+    get:
+      operationId: listReports
+      tags: [legacy]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/LegacyReport"
+components:
+  schemas:
+    LegacyReport:
+      type: object
+      properties:
+        id: {type: string}
+tags:
+  - name: users
+  - name: legacy
+    description: Hand-written operations
+`
+
+const healthFragment = `{
+  "paths": {
+    "/health": {
+      "get": {
+        "operationId": "health",
+        "responses": {"204": {"description": "No Content"}}
+      }
+    }
+  }
+}`
+
+type CreateJobRequest struct {
+	Name        string `json:"name"         validate:"required"`
+	CallbackURL string `json:"callback_url" validate:"required"`
+	FallbackURL string `json:"fallback_url"`
+}
+
+type Job struct {
+	ID     string `json:"id"`
+	Status string `json:"status" enum:"queued,running,done"`
+}
+
+type JobXML struct {
+	ID     string `json:"id"     xml:"id,attr"`
+	Status string `json:"status" xml:"status"`
+}
+
+type ErrorBody struct {
+	Code    int    `json:"code"    required:"true"`
+	Message string `json:"message" required:"true"`
+}
+
+type PageParam struct {
+	Page int `query:"page" minimum:"1" default:"1" description:"Page number"`
+}
+
+type TraceParam struct {
+	TraceID string `header:"X-Trace-Id"`
+}
+
+type TenantPath struct {
+	TenantID string `path:"tenantId" description:"Tenant identifier"`
+}
+
+type GetTenantJobRequest struct {
+	TenantPath
+	ID string `path:"jobId"`
+}
+
+type GetJobRequest struct {
+	ID string `path:"jobId"`
+}
+
+type GetJobByIDRequest struct {
+	ID string `path:"id"`
+}
+
+type RateLimitHeaders struct {
+	Limit     int `header:"X-RateLimit-Limit"     description:"Requests allowed per minute" required:"true"`
+	Remaining int `header:"X-RateLimit-Remaining" description:"Requests left in the window"`
+}
+
 type CustomParser struct {
 	re *regexp.Regexp
 }
@@ -646,6 +743,298 @@ func TestRouter(t *testing.T) {
 				)
 			},
 		},
+		{
+			name:   "Fragments",
+			golden: "fragments",
+			opts: []option.OpenAPIOption{
+				option.WithTags(openapi.Tag{Name: "users"}),
+				option.WithFragment([]byte(legacyFragment)),
+				option.WithFragmentFS(fstest.MapFS{
+					"fragments/health.json": {Data: []byte(healthFragment)},
+				}, "fragments/*.json"),
+			},
+			setup: func(r spec.Router) {
+				r.Get("/users",
+					option.OperationID("listUsers"),
+					option.Tags("users"),
+					option.Response(200, new([]User)),
+				)
+			},
+		},
+		{
+			name:   "Callbacks",
+			golden: "callbacks",
+			setup: func(r spec.Router) {
+				r.Post("/jobs",
+					option.OperationID("createJob"),
+					option.Request(new(CreateJobRequest)),
+					option.Response(202, new(Job)),
+					option.Callback("onComplete", "{$request.body#/callback_url}", func(cb option.CallbackRouter) {
+						cb.Post(
+							option.Summary("Job completed"),
+							option.Request(new(Job)),
+							option.Response(204, nil),
+						)
+						cb.Put(option.Hidden())
+					}),
+					option.Callback("onComplete", "{$request.body#/fallback_url}", func(cb option.CallbackRouter) {
+						cb.Post(option.Request(new(Job)))
+					}),
+					option.Callback("onProgress", "{$request.body#/callback_url}/progress", func(cb option.CallbackRouter) {
+						cb.Post(
+							option.Request(new(struct {
+								Percent int `json:"percent"`
+							})),
+							option.Response(200, nil),
+						)
+					}),
+				)
+			},
+		},
+		{
+			name:   "Response Links",
+			golden: "response_links",
+			setup: func(r spec.Router) {
+				r.Post("/jobs",
+					option.OperationID("createJob"),
+					option.Request(new(CreateJobRequest)),
+					option.Response(202, new(Job),
+						option.ResponseLink("GetJob",
+							option.LinkOperationID("getJob"),
+							option.LinkParam("jobId", "$response.body#/id"),
+							option.LinkDescription("The id of the created job can be used to get its status."),
+						),
+						option.ResponseLink("CancelJob",
+							option.LinkOperationRef("#/paths/~1jobs~1{jobId}/delete"),
+							option.LinkParam("jobId", "$response.body#/id"),
+						),
+					),
+				)
+				r.Get("/jobs/{jobId}",
+					option.OperationID("getJob"),
+					option.Request(new(GetJobRequest)),
+					option.Response(200, new(Job)),
+				)
+				r.Delete("/jobs/{jobId}",
+					option.OperationID("cancelJob"),
+					option.Request(new(GetJobRequest)),
+					option.Response(204, nil,
+						option.ResponseLink("CreateJob",
+							option.LinkOperationID("createJob"),
+							option.LinkRequestBody("$request.body"),
+						),
+					),
+				)
+			},
+		},
+		{
+			name:   "Response Headers",
+			golden: "response_headers",
+			setup: func(r spec.Router) {
+				r.Post("/pets",
+					option.Request(new(PetEvent)),
+					option.Response(201, new(PetEvent),
+						option.ResponseHeader("Location", "", "URL of the created pet"),
+						option.ResponseHeaders(new(RateLimitHeaders)),
+					),
+					option.Response(429, nil,
+						option.ResponseHeader("Retry-After", 0, "Seconds to wait before retrying"),
+						option.ResponseHeaders(new(RateLimitHeaders)),
+					),
+				)
+			},
+		},
+		{
+			name:   "Content Examples",
+			golden: "content_examples",
+			setup: func(r spec.Router) {
+				r.Post("/pets",
+					option.Request(new(PetEvent),
+						option.ContentExamples(map[string]openapi.Example{
+							"rex": {
+								Summary: "A dog",
+								Value:   PetEvent{ID: 1, Name: "Rex"},
+							},
+							"remote": {
+								Summary:       "Stored example",
+								ExternalValue: "https://example.com/examples/pet.json",
+							},
+						}),
+					),
+					option.Response(201, new(PetEvent),
+						option.ContentExample(PetEvent{
+							ID:        1,
+							Name:      "Rex",
+							CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+						}),
+					),
+				)
+			},
+		},
+		{
+			name:   "Multiple Media Types",
+			golden: "multiple_media_types",
+			setup: func(r spec.Router) {
+				r.Post("/jobs",
+					option.Request(new(CreateJobRequest)),
+					option.Request(new(JobXML), option.ContentType("application/xml")),
+					option.Request("", option.ContentType("text/csv"),
+						option.ContentExample("name,callback_url\nreport,https://example.com/hook"),
+					),
+					option.Response(201, new(Job),
+						option.ContentExample(Job{ID: "42", Status: "queued"}),
+					),
+					option.Response(201, new(JobXML),
+						option.ContentType("application/xml"),
+						option.ContentExample(`<job id="42"><status>queued</status></job>`),
+					),
+					option.Response(201, "",
+						option.ContentType("text/csv; charset=utf-8"),
+						option.ContentDescription("Job created"),
+					),
+				)
+			},
+		},
+		{
+			name:   "Reusable Components",
+			golden: "reusable_components",
+			opts: []option.OpenAPIOption{
+				option.WithComponentResponse("NotFound", 404, new(ErrorBody),
+					option.ContentDescription("Resource not found"),
+				),
+				option.WithComponentResponse("Error", 500, new(ErrorBody)),
+				option.WithComponentParameter("Page", new(PageParam)),
+				option.WithComponentParameter("TraceID", new(TraceParam)),
+				option.WithComponentRequestBody("CreateJob", new(CreateJobRequest),
+					option.ContentDescription("Job to create"),
+				),
+				option.WithComponentHeader("RetryAfter", 0, "Seconds to wait before retrying"),
+			},
+			setup: func(r spec.Router) {
+				r.Get("/jobs",
+					option.ParameterRef("Page", "TraceID"),
+					option.Response(200, new([]Job)),
+					option.ResponseRef("Error"),
+				)
+				r.Post("/jobs",
+					option.RequestBodyRef("CreateJob"),
+					option.Response(202, new(Job)),
+					option.Response(429, nil, option.ResponseHeaderRef("Retry-After", "RetryAfter")),
+					option.ResponseRef("Error", 503),
+				)
+				r.Get("/jobs/{jobId}",
+					option.Request(new(GetJobRequest)),
+					option.Response(200, new(Job)),
+					option.ResponseRef("NotFound"),
+					option.ResponseRef("Error"),
+				)
+			},
+		},
+		{
+			name:   "Group Operation Options",
+			golden: "group_operation_options",
+			setup: func(r spec.Router) {
+				admin := r.Group("/admin",
+					option.GroupTags("Admin"),
+					option.GroupRequest(new(TraceParam)),
+					option.GroupResponse(401, new(ErrorBody), option.ContentDescription("Unauthorized")),
+					option.GroupResponse(500, new(ErrorBody)),
+					option.GroupSummaryPrefix("Admin: "),
+					option.GroupOperationIDPrefix("admin"),
+				)
+				admin.Get("/jobs",
+					option.OperationID("ListJobs"),
+					option.Summary("List jobs"),
+					option.Response(200, new([]Job)),
+				)
+				admin.Route("/jobs/{jobId}", func(r spec.Router) {
+					r.Get("/",
+						option.OperationID("GetJob"),
+						option.Summary("Get job"),
+						option.Response(200, new(Job)),
+					)
+					r.Delete("/")
+				}, option.GroupRequest(new(GetJobRequest)),
+					option.GroupOperationOptions(option.Description("Operations on a single job.")),
+					option.GroupOperationIDPrefix("Job"),
+					option.GroupResponse(404, new(ErrorBody)),
+				)
+			},
+		},
+		{
+			name:   "Group Params",
+			golden: "group_params",
+			opts: []option.OpenAPIOption{
+				option.WithPathParser(parser.NewColonParamParser()),
+			},
+			setup: func(r spec.Router) {
+				tenant := r.Group("/tenants/:tenantId", option.GroupParams(new(TenantPath)))
+				tenant.Get("/jobs", option.Response(200, new([]Job)))
+				tenant.Route("/jobs/:jobId", func(r spec.Router) {
+					// Redeclaring the group parameter in the request is allowed.
+					r.Get("/", option.Request(new(GetTenantJobRequest)), option.Response(200, new(Job)))
+					r.Delete("/", option.Request(new(GetJobRequest)), option.Response(204, nil))
+				})
+			},
+		},
+		{
+			name:   "Security Schemes",
+			golden: "security_schemes",
+			opts: []option.OpenAPIOption{
+				option.WithSecurity("basicAuth", option.SecurityHTTPBasic(),
+					option.SecurityDescription("Internal service credentials"),
+				),
+				option.WithSecurity("digestAuth", option.SecurityHTTP("digest")),
+				option.WithSecurity("oidc", option.SecurityOpenIDConnect(
+					"https://auth.example.com/.well-known/openid-configuration",
+				)),
+			},
+			setup: func(r spec.Router) {
+				r.Get("/internal/jobs", option.Security("basicAuth"), option.Security("digestAuth"),
+					option.Response(200, new([]Job)),
+				)
+				r.Get("/jobs", option.Security("oidc", "openid", "jobs:read"), option.Response(200, new([]Job)))
+			},
+		},
+		{
+			name:   "Security Requirements",
+			golden: "security_requirements",
+			opts: []option.OpenAPIOption{
+				option.WithSecurity("bearerAuth", option.SecurityHTTPBearer("Bearer")),
+				option.WithSecurity("apiKey", option.SecurityAPIKey("X-API-Key", openapi.SecuritySchemeAPIKeyInHeader)),
+			},
+			setup: func(r spec.Router) {
+				jobs := r.Group("/jobs", option.GroupSecurity("bearerAuth"))
+				jobs.Get("/", option.SecurityOptional(), option.Response(200, new([]Job)))
+				jobs.Get("/public", option.NoSecurity(), option.Response(200, new([]Job)))
+				jobs.Post("/",
+					option.NoSecurity(),
+					option.SecurityAll(
+						option.SecurityRequirement("apiKey"),
+						option.SecurityRequirement("bearerAuth"),
+					),
+					option.Response(201, new(Job)),
+				)
+				admin := r.Group("/admin", option.GroupSecurityAll(
+					option.SecurityRequirement("apiKey"),
+					option.SecurityRequirement("bearerAuth"),
+				))
+				admin.Delete("/jobs", option.Response(204, nil))
+			},
+		},
+		{
+			name:   "Operation ID Func",
+			golden: "operation_id_func",
+			opts: []option.OpenAPIOption{
+				option.WithOperationIDFunc(option.OperationIDCamelCase),
+			},
+			setup: func(r spec.Router) {
+				r.Get("/jobs", option.Response(200, new([]Job)))
+				r.Post("/jobs", option.OperationID("submitJob"), option.Response(201, new(Job)))
+				r.Get("/jobs/{jobId}", option.Request(new(GetJobRequest)), option.Response(200, new(Job)))
+				r.Delete("/jobs/{jobId}", option.Request(new(GetJobRequest)), option.Response(204, nil))
+			},
+		},
 		{
 			name: "Invalid OpenAPI Version",
 			opts: []option.OpenAPIOption{
@@ -653,6 +1042,15 @@ func TestRouter(t *testing.T) {
 			},
 			shouldError: true,
 		},
+		{
+			name: "Undefined Group Path Parameter",
+			setup: func(r spec.Router) {
+				tenant := r.Group("/tenants/{tenantId}", option.GroupParams(new(TenantPath)))
+				tenant.Get("/jobs", option.Response(200, new([]Job)))
+				r.Group("/tenants/{tenantId}/users").Get("/", option.Response(200, nil))
+			},
+			shouldError: true,
+		},
 		{
 			name: "Invalid URL Path Parameter",
 			setup: func(r spec.Router) {
@@ -667,6 +1065,67 @@ func TestRouter(t *testing.T) {
 			},
 			shouldError: true, // Invalid path parameter without a proper tag
 		},
+		{
+			name: "Duplicate Callback Operation",
+			setup: func(r spec.Router) {
+				r.Post("/jobs",
+					option.Request(new(CreateJobRequest)),
+					option.Callback("onComplete", "{$request.body#/callback_url}", func(cb option.CallbackRouter) {
+						cb.Post(option.Request(new(Job)))
+						cb.Post(option.Request(new(Job)))
+					}),
+				)
+			},
+			shouldError: true,
+		},
+		{
+			name: "Undefined Link Operation",
+			setup: func(r spec.Router) {
+				r.Post("/jobs",
+					option.Request(new(CreateJobRequest)),
+					option.Response(202, new(Job),
+						option.ResponseLink("GetJob", option.LinkOperationID("getJob")),
+					),
+				)
+			},
+			shouldError: true,
+		},
+		{
+			name: "Unmarshalable Content Example",
+			setup: func(r spec.Router) {
+				r.Get("/jobs",
+					option.Response(200, new([]Job), option.ContentExample(make(chan int))),
+				)
+			},
+			shouldError: true,
+		},
+		{
+			name: "Undefined Response Component",
+			setup: func(r spec.Router) {
+				r.Get("/jobs", option.ResponseRef("NotFound"))
+			},
+			shouldError: true,
+		},
+		{
+			name: "Response Defined Inline And By Reference",
+			opts: []option.OpenAPIOption{
+				option.WithComponentResponse("NotFound", 404, new(ErrorBody)),
+			},
+			setup: func(r spec.Router) {
+				r.Get("/jobs", option.Response(404, nil), option.ResponseRef("NotFound"))
+			},
+			shouldError: true,
+		},
+		{
+			name: "Invalid Parameter Component",
+			opts: []option.OpenAPIOption{
+				option.WithComponentParameter("Paging", new(struct {
+					Page  int `query:"page"`
+					Limit int `query:"limit"`
+				})),
+			},
+			shouldError: true,
+		},
 		{
 			name: "Error Custom Path Parser",
 			opts: []option.OpenAPIOption{
@@ -912,3 +1371,758 @@ func TestRouter_WriteSchemaTo(t *testing.T) {
 		})
 	}
 }
+
+func TestRouter_Fragments(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []option.OpenAPIOption
+		errorMsg []string
+	}{
+		{
+			name: "Operation already defined",
+			opts: []option.OpenAPIOption{
+				option.WithFragment([]byte(`{"paths": {"/users": {"get": {"responses": {}}}}}`)),
+			},
+			errorMsg: []string{"fragment #1: operation GET /users is already defined"},
+		},
+		{
+			name: "Component already defined",
+			opts: []option.OpenAPIOption{
+				option.WithFragment([]byte(`
+components:
+  schemas:
+    SpecTestToken:
+      type: string
+`)),
+			},
+			errorMsg: []string{`fragment #1: component schemas "SpecTestToken" is already defined`},
+		},
+		{
+			name: "Tag already defined",
+			opts: []option.OpenAPIOption{
+				option.WithTags(openapi.Tag{Name: "users", Description: "Users"}),
+				option.WithFragment([]byte(`{"tags": [{"name": "users", "description": "People"}]}`)),
+			},
+			errorMsg: []string{`fragment #1: tags entry "users" is already defined`},
+		},
+		{
+			name: "Info already defined",
+			opts: []option.OpenAPIOption{
+				option.WithFragment([]byte(`{"info": {"title": "Other"}}`)),
+			},
+			errorMsg: []string{"fragment #1: #/info/title is already defined"},
+		},
+		{
+			name: "Version mismatch",
+			opts: []option.OpenAPIOption{
+				option.WithFragment([]byte(`openapi: 2.0.0`)),
+			},
+			errorMsg: []string{"fragment #1: openapi version \"2.0.0\" does not match"},
+		},
+		{
+			name: "Invalid document",
+			opts: []option.OpenAPIOption{
+				option.WithFragment([]byte(`{`)),
+			},
+			errorMsg: []string{"fragment #1:"},
+		},
+		{
+			name: "No matching files",
+			opts: []option.OpenAPIOption{
+				option.WithFragmentFS(fstest.MapFS{}, "fragments/*.yaml"),
+			},
+			errorMsg: []string{"fragment fragments/*.yaml: no files match the pattern"},
+		},
+		{
+			name: "Multiple conflicts",
+			opts: []option.OpenAPIOption{
+				option.WithFragmentFS(fstest.MapFS{
+					"a.json": {Data: []byte(`{"paths": {"/users": {"get": {"responses": {}}}}}`)},
+					"b.json": {Data: []byte(`{"paths": {"/users": {"get": {"responses": {}}}}}`)},
+				}, "*.json"),
+			},
+			errorMsg: []string{
+				"fragment a.json: operation GET /users is already defined",
+				"fragment b.json: operation GET /users is already defined",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, version := range []string{"3.0.3", "3.1.0"} {
+				opts := append([]option.OpenAPIOption{
+					option.WithOpenAPIVersion(version),
+					option.WithTitle("Test API"),
+					option.WithVersion("1.0.0"),
+				}, tt.opts...)
+				r := spec.NewRouter(opts...)
+				r.Get("/users", option.Response(200, new(Token)))
+
+				err := r.Validate()
+				require.Error(t, err)
+				for _, msg := range tt.errorMsg {
+					assert.Contains(t, err.Error(), msg)
+				}
+			}
+		})
+	}
+}
+
+type PetEvent struct {
+	ID        int       `json:"id"         required:"true"`
+	Name      string    `json:"name"       required:"true"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func TestRouter_Webhook(t *testing.T) {
+	setup := func(version string) spec.Generator {
+		r := spec.NewRouter(
+			option.WithOpenAPIVersion(version),
+			option.WithTitle("API Doc: Webhooks"),
+			option.WithVersion("1.0.0"),
+		)
+		r.Get("/pets", option.Response(200, new([]PetEvent)))
+		r.Webhook("petCreated", "POST",
+			option.OperationID("petCreated"),
+			option.Summary("Pet created"),
+			option.Tags("pets"),
+			option.Request(new(PetEvent)),
+			option.Response(200, nil, option.ContentDescription("Event received")),
+		)
+		r.Webhook("petCreated", "PUT",
+			option.Summary("Pet replayed"),
+			option.Request(new(PetEvent)),
+		)
+		r.Webhook("petDeleted", "POST",
+			option.Summary("Pet deleted"),
+			option.Request(new(struct {
+				ID int `json:"id"`
+			})),
+		)
+		return r
+	}
+
+	t.Run("OpenAPI 3.1", func(t *testing.T) {
+		schema, err := setup("3.1.0").GenerateSchema("yaml")
+		require.NoError(t, err)
+
+		goldenFile := filepath.Join("testdata", "webhooks_31.yaml")
+		if *update {
+			err = os.WriteFile(goldenFile, schema, 0644)
+			require.NoError(t, err, "failed to write golden file")
+		}
+		want, err := os.ReadFile(goldenFile)
+		require.NoError(t, err, "failed to read golden file %s", goldenFile)
+
+		testutil.EqualYAML(t, want, schema)
+	})
+
+	t.Run("OpenAPI 3.0", func(t *testing.T) {
+		err := setup("3.0.3").Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `webhook POST "petCreated" requires OpenAPI 3.1, but version 3.0.3 is configured`)
+		assert.Contains(t, err.Error(), `webhook POST "petDeleted" requires OpenAPI 3.1`)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		r := spec.NewRouter(option.WithOpenAPIVersion("3.1.0"))
+		r.Webhook("petCreated", "POST")
+		r.Webhook("petCreated", "POST")
+
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "webhook already exists: POST petCreated")
+	})
+}
+
+func TestRouter_SecurityReferences(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		r := spec.NewRouter(
+			option.WithOpenAPIVersion(version),
+			option.WithSecurity("bearerAuth", option.SecurityHTTPBearer("Bearer")),
+			option.WithSecurity("oauth2", option.SecurityOAuth2(openapi.OAuthFlows{
+				ClientCredentials: &openapi.OAuthFlowsClientCredentials{
+					TokenURL: "https://auth.example.com/token",
+					Scopes:   map[string]string{"jobs:read": "Read jobs"},
+				},
+			})),
+		)
+		r.Get("/jobs", option.Security("oauth2", "jobs:read"), option.Response(200, new([]Job)))
+		r.Post("/jobs", option.Security("oauth2", "jobs:write"), option.Response(201, new(Job)))
+		admin := r.Group("/admin", option.GroupSecurity("bearerAuht"))
+		admin.Delete("/jobs", option.SecurityOptional(), option.Response(204, nil))
+
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `DELETE /admin/jobs requires undefined security scheme "bearerAuht"`)
+		assert.Contains(t, err.Error(),
+			`POST /jobs requires scope "jobs:write", which security scheme "oauth2" does not declare`)
+		assert.NotContains(t, err.Error(), "GET /jobs")
+	}
+}
+
+func TestRouter_NoSecurity(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		t.Run(version, func(t *testing.T) {
+			r := spec.NewRouter(
+				option.WithOpenAPIVersion(version),
+				option.WithSecurity("bearerAuth", option.SecurityHTTPBearer("Bearer")),
+				option.WithFragment([]byte(`{"security": [{"bearerAuth": []}]}`)),
+			)
+			r.Get("/jobs", option.Response(200, new([]Job)))
+			r.Get("/health", option.Security("bearerAuth"), option.NoSecurity(), option.Response(204, nil))
+
+			schema, err := r.MarshalJSON()
+			require.NoError(t, err)
+
+			var doc struct {
+				Security []map[string][]string                `json:"security"`
+				Paths    map[string]map[string]map[string]any `json:"paths"`
+			}
+			require.NoError(t, json.Unmarshal(schema, &doc))
+			assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, doc.Security)
+			assert.NotContains(t, doc.Paths["/jobs"]["get"], "security")
+			assert.Equal(t, []any{}, doc.Paths["/health"]["get"]["security"])
+		})
+	}
+}
+
+func TestRouter_NoSecurityTransformed(t *testing.T) {
+	secure := []map[string][]string{{"bearerAuth": {"admin"}}}
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		t.Run(version, func(t *testing.T) {
+			r := spec.NewRouter(
+				option.WithOpenAPIVersion(version),
+				option.WithSecurity("bearerAuth", option.SecurityHTTPBearer("Bearer")),
+				option.WithFragment([]byte(`{"security": [{"bearerAuth": []}]}`)),
+				option.WithSpecTransformer3(func(doc *openapi3.Spec) error {
+					op := doc.Paths.MapOfPathItemValues["/health"].MapOfOperationValues["get"]
+					op.Security = secure
+					doc.Paths.MapOfPathItemValues["/health"].MapOfOperationValues["get"] = op
+					return nil
+				}),
+				option.WithSpecTransformer31(func(doc *openapi31.Spec) error {
+					doc.Paths.MapOfPathItemValues["/health"].Get.Security = secure
+					return nil
+				}),
+			)
+			r.Get("/health", option.NoSecurity(), option.Response(204, nil))
+
+			require.NoError(t, r.Validate())
+			schema, err := r.MarshalJSON()
+			require.NoError(t, err)
+			assert.Equal(t, 2, strings.Count(string(schema), `"security"`), "security of the document and the operation")
+
+			var doc struct {
+				Paths map[string]map[string]map[string]any `json:"paths"`
+			}
+			require.NoError(t, json.Unmarshal(schema, &doc))
+			assert.Equal(t, []any{map[string]any{"bearerAuth": []any{"admin"}}}, doc.Paths["/health"]["get"]["security"])
+		})
+	}
+}
+
+func TestRouter_DuplicateOperationIDs(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		r := spec.NewRouter(
+			option.WithOpenAPIVersion(version),
+			option.WithOperationIDFunc(func(method, _ string, _ *option.OperationConfig) string {
+				return strings.ToLower(method) + "Job"
+			}),
+		)
+		r.Get("/jobs", option.Response(200, new([]Job)))
+		r.Get("/jobs/{jobId}", option.Request(new(GetJobRequest)), option.Response(200, new(Job)))
+		r.Post("/jobs", option.OperationID("getJob"), option.Response(201, new(Job)))
+
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `operationId "getJob" of POST /jobs is already used by GET /jobs`)
+		assert.Contains(t, err.Error(), `operationId "getJob" of GET /jobs/{jobId} is already used by GET /jobs`)
+	}
+}
+
+func TestRouter_OperationIDPrefix(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		r := spec.NewRouter(
+			option.WithOpenAPIVersion(version),
+			option.WithOperationIDFunc(option.OperationIDCamelCase),
+		)
+		admin := r.Group("/admin", option.GroupOperationIDPrefix("admin_"))
+		admin.Get("/pets", option.Response(200, new([]Job)))
+		admin.Get("/users", option.OperationID("listUsers"), option.Response(200, new([]Job)))
+		admin.Post("/pets", option.OperationID("getAdminPets"), option.Response(201, new(Job)))
+
+		var ids []string
+		for _, op := range r.Operations() {
+			ids = append(ids, op.Config.OperationID)
+		}
+		assert.Equal(t, []string{"admin_getAdminPets", "admin_listUsers", "admin_getAdminPets"}, ids)
+
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			`operationId "admin_getAdminPets" of POST /admin/pets is already used by GET /admin/pets`)
+	}
+}
+
+func TestRouter_RequestBodyRef(t *testing.T) {
+	type JobLabels struct {
+		Names []string `json:"names"`
+	}
+	type UpdateJobRequest struct {
+		ID     string           `path:"jobId"`
+		Job    Job              `json:"job"`
+		Owner  CreateJobRequest `json:"owner"`
+		Labels JobLabels        `json:"labels"`
+	}
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		t.Run(version, func(t *testing.T) {
+			r := spec.NewRouter(
+				option.WithOpenAPIVersion(version),
+				option.WithComponentRequestBody("CreateJob", new(CreateJobRequest)),
+			)
+			r.Put("/jobs/{jobId}",
+				option.Request(new(UpdateJobRequest)),
+				option.RequestBodyRef("CreateJob"),
+				option.Response(200, new(Job)),
+			)
+			schema, err := r.MarshalJSON()
+			require.NoError(t, err)
+
+			var doc struct {
+				Paths      map[string]map[string]map[string]any `json:"paths"`
+				Components struct {
+					Schemas map[string]any `json:"schemas"`
+				} `json:"components"`
+			}
+			require.NoError(t, json.Unmarshal(schema, &doc))
+			schemas := make([]string, 0, len(doc.Components.Schemas))
+			for name := range doc.Components.Schemas {
+				schemas = append(schemas, name)
+			}
+			assert.ElementsMatch(t, []string{"SpecTestCreateJobRequest", "SpecTestJob"}, schemas)
+			operation := doc.Paths["/jobs/{jobId}"]["put"]
+			assert.Equal(t, map[string]any{"$ref": "#/components/requestBodies/CreateJob"}, operation["requestBody"])
+			assert.Len(t, operation["parameters"], 1)
+		})
+	}
+}
+
+func TestRouter_ConflictingRoutes(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		r := spec.NewRouter(
+			option.WithOpenAPIVersion(version),
+			option.WithPathParser(parser.NewColonParamParser()),
+		)
+		r.Get("/jobs", option.OperationID("listJobs"), option.Response(200, new([]Job)))
+		r.Get("/jobs/{jobId}", option.Request(new(GetJobRequest)), option.Response(200, new(Job)))
+		r.Get("/jobs/:jobId/logs", option.Request(new(GetJobRequest)))
+		r.Put("/jobs/{jobId}", option.Request(new(GetJobRequest)))
+		r.Get("/jobs/{id}/logs", option.Request(new(GetJobByIDRequest)), option.Hidden())
+		r.Get("/jobs", option.Hidden())
+
+		api := r.Group("/api/")
+		api.Get("/jobs")
+		r.Group("/").Get("/jobs", option.OperationID("searchJobs"))
+		r.Route("/jobs", func(r spec.Router) {
+			r.Get("/{id}", option.Request(new(GetJobByIDRequest)))
+		})
+		api.Get("jobs")
+
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `route GET /jobs (operationId "searchJobs") conflicts with `+
+			`GET /jobs (operationId "listJobs"): same method and path`)
+		assert.Contains(t, err.Error(),
+			"route GET /jobs/{id} conflicts with GET /jobs/{jobId}: paths differ only in parameter names")
+		assert.Contains(t, err.Error(), "route GET /api/jobs conflicts with GET /api/jobs: same method and path")
+		assert.Equal(t, 3, strings.Count(err.Error(), "conflicts with"))
+		assert.NotContains(t, err.Error(), "already exists")
+	}
+}
+
+func TestRouter_PathParams(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		r := spec.NewRouter(
+			option.WithOpenAPIVersion(version),
+			option.WithPathParser(parser.NewColonParamParser()),
+		)
+		r.Get("/jobs/:jobId", option.Request(new(GetJobRequest)), option.Response(200, new(Job)))
+		r.Get("/jobs/:id/logs", option.Request(new(GetJobRequest)))
+		r.Post("/jobs/:jobId/retry", option.Hidden())
+
+		tenants := r.Group("/tenants/:tenantId", option.GroupParams(new(TenantPath)))
+		tenants.Get("/jobs/:jobId", option.Request(new(GetJobRequest)))
+		tenants.Get("/jobs", option.Request(new(GetTenantJobRequest)))
+
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"GET /jobs/{id}/logs: path parameter \"id\" is not declared by a `path` field of the request")
+		assert.Contains(t, err.Error(),
+			`GET /jobs/{id}/logs: field GetJobRequest.ID declares path parameter "jobId", which is not in the path`)
+		assert.Contains(t, err.Error(), `GET /tenants/{tenantId}/jobs: field GetTenantJobRequest.ID `+
+			`declares path parameter "jobId", which is not in the path`)
+		assert.Len(t, strings.Split(strings.TrimSpace(err.Error()), "\n"), 4)
+	}
+}
+
+func TestRouter_Operations(t *testing.T) {
+	r := spec.NewRouter(
+		option.WithPathParser(parser.NewColonParamParser()),
+		option.WithOperationIDFunc(option.OperationIDCamelCase),
+		option.WithSecurity("bearerAuth", option.SecurityHTTPBearer("Bearer")),
+	)
+	r.Get("/health", option.OperationID("health"))
+	api := r.Group("/api", option.GroupTags("API"), option.GroupSecurity("bearerAuth"))
+	api.Get("/jobs/:jobId", option.Request(new(GetJobRequest)), option.Response(200, new(Job)))
+	api.Route("/internal", func(r spec.Router) {
+		r.Post("/reindex")
+	}, option.GroupHidden())
+	api.Delete("/jobs/:jobId", option.Hidden())
+	r.NewRoute(option.Summary("Incomplete"))
+
+	ops := r.Operations()
+	require.Len(t, ops, 4)
+
+	assert.Equal(t, "GET", ops[0].Method)
+	assert.Equal(t, "/health", ops[0].Path)
+	assert.Equal(t, "health", ops[0].Config.OperationID)
+	assert.False(t, ops[0].Hidden)
+
+	assert.Equal(t, "GET", ops[1].Method)
+	assert.Equal(t, "/api/jobs/{jobId}", ops[1].Path)
+	assert.Equal(t, "getApiJobsByJobId", ops[1].Config.OperationID)
+	assert.Equal(t, []string{"API"}, ops[1].Config.Tags)
+	assert.Equal(t, []option.OperationSecurityConfig{{Name: "bearerAuth"}}, ops[1].Config.Security)
+	assert.Len(t, ops[1].Config.Requests, 1)
+	assert.Len(t, ops[1].Config.Responses, 1)
+	assert.False(t, ops[1].Hidden)
+
+	assert.Equal(t, "DELETE", ops[2].Method)
+	assert.True(t, ops[2].Hidden)
+	assert.Empty(t, ops[2].Config.OperationID)
+
+	assert.Equal(t, "POST", ops[3].Method)
+	assert.Equal(t, "/api/internal/reindex", ops[3].Path)
+	assert.True(t, ops[3].Hidden)
+
+	t.Run("returns new configurations", func(t *testing.T) {
+		ops[1].Config.Tags[0] = "Changed"
+		assert.Equal(t, []string{"API"}, r.Operations()[1].Config.Tags)
+	})
+
+	t.Run("does not change the specification", func(t *testing.T) {
+		schema, err := r.MarshalJSON()
+		require.NoError(t, err)
+		assert.NotContains(t, string(schema), "reindex")
+		assert.Contains(t, string(schema), `"operationId": "getApiJobsByJobId"`)
+		assert.Len(t, r.Operations(), 4)
+	})
+}
+
+func TestRouter_MutualTLS(t *testing.T) {
+	setup := func(version string) spec.Generator {
+		r := spec.NewRouter(
+			option.WithOpenAPIVersion(version),
+			option.WithSecurity("mtls", option.SecurityMutualTLS(), option.SecurityDescription("Client certificate")),
+		)
+		r.Get("/jobs", option.Security("mtls"), option.Response(200, new([]Job)))
+		return r
+	}
+
+	t.Run("OpenAPI 3.1", func(t *testing.T) {
+		schema, err := setup("3.1.0").MarshalJSON()
+		require.NoError(t, err)
+
+		var doc struct {
+			Components struct {
+				SecuritySchemes map[string]map[string]any `json:"securitySchemes"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(schema, &doc))
+		assert.Equal(t, map[string]any{
+			"type":        "mutualTLS",
+			"description": "Client certificate",
+		}, doc.Components.SecuritySchemes["mtls"])
+	})
+
+	t.Run("OpenAPI 3.0", func(t *testing.T) {
+		err := setup("3.0.3").Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			`security scheme "mtls": mutualTLS requires OpenAPI 3.1, but version 3.0.3 is configured`)
+	})
+}
+
+func TestRouter_SpecTransformer(t *testing.T) {
+	setup := func(version string, opts ...option.OpenAPIOption) spec.Generator {
+		opts = append([]option.OpenAPIOption{
+			option.WithOpenAPIVersion(version),
+			option.WithSpecTransformer3(func(doc *openapi3.Spec) error {
+				doc.WithMapOfAnythingItem("x-transformed", "3.0")
+				return nil
+			}),
+			option.WithSpecTransformer31(func(doc *openapi31.Spec) error {
+				doc.WithMapOfAnythingItem("x-transformed", "3.1")
+				return nil
+			}),
+		}, opts...)
+		r := spec.NewRouter(opts...)
+		r.Get("/jobs", option.Response(200, new([]Job)))
+		return r
+	}
+
+	for _, tc := range []struct {
+		version string
+		want    string
+	}{
+		{"3.0.3", "3.0"},
+		{"3.1.0", "3.1"},
+	} {
+		t.Run(tc.version, func(t *testing.T) {
+			schema, err := setup(tc.version).MarshalJSON()
+			require.NoError(t, err)
+
+			var doc map[string]any
+			require.NoError(t, json.Unmarshal(schema, &doc))
+			assert.Equal(t, tc.want, doc["x-transformed"])
+		})
+	}
+
+	t.Run("Error", func(t *testing.T) {
+		r := setup("3.1.0", option.WithSpecTransformer(func(any) error {
+			return errors.New("missing x-api-id")
+		}))
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "spec transformer #3: missing x-api-id")
+	})
+
+	t.Run("Checked", func(t *testing.T) {
+		r := setup("3.0.3", option.WithSpecTransformer3(func(doc *openapi3.Spec) error {
+			item := doc.Paths.MapOfPathItemValues["/jobs"]
+			op := item.MapOfOperationValues["get"]
+			op.Security = append(op.Security, map[string][]string{"apiKey": {}})
+			item.MapOfOperationValues["get"] = op
+			return nil
+		}))
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `GET /jobs requires undefined security scheme "apiKey"`)
+	})
+}
+
+func TestRouter_SchemaValidation(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		t.Run(version, func(t *testing.T) {
+			r := spec.NewRouter(option.WithOpenAPIVersion(version), option.WithVersion("1.0.0"))
+			r.Get("/jobs", option.Response(200, new([]Job)))
+			require.NoError(t, r.Validate(spec.WithSchemaValidation()))
+
+			r = spec.NewRouter(
+				option.WithOpenAPIVersion(version),
+				option.WithServer("https://{region}.example.com", option.ServerVariables(map[string]openapi.ServerVariable{
+					"region": {Enum: []string{"eu", "us"}, Default: "eu"},
+				})),
+			)
+			r.Get("/jobs", option.Response(200, new([]Job)))
+			require.NoError(t, r.Validate(), "the generator does not check the document structure")
+
+			err := r.Validate(spec.WithSchemaValidation())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "openapi schema: #/info: missing properties: 'version'")
+			assert.NotContains(t, err.Error(), "#/servers", "templated server URLs are valid")
+
+			var schemaErr *spec.SchemaError
+			require.ErrorAs(t, err, &schemaErr)
+			assert.Equal(t, "#/info", schemaErr.Pointer)
+		})
+	}
+
+	r := spec.NewRouter(option.WithOpenAPIVersion("3.1.0"), option.WithVersion("1.0.0"),
+		option.WithServer("https://api example.com"))
+	r.Get("/jobs", option.Response(200, new([]Job)))
+	err := r.Validate(spec.WithSchemaValidation())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "openapi schema: #/servers/0/url: ")
+
+	r = spec.NewRouter(option.WithOpenAPIVersion("3.1.0"), option.WithVersion("1.0.0"))
+	r.Get("/jobs/{id}", option.Response(200, new(Job)))
+	err = r.Validate(spec.WithSchemaValidation())
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "openapi schema", "build errors are reported first")
+}
+
+func TestRouter_Document(t *testing.T) {
+	t.Run("OpenAPI 3.0", func(t *testing.T) {
+		r := spec.NewRouter(option.WithOpenAPIVersion("3.0.3"), option.WithTitle("Jobs"))
+		r.Get("/jobs", option.Response(200, new([]Job)))
+
+		doc, ok := r.Document().(*openapi3.Spec)
+		require.True(t, ok)
+		assert.Equal(t, "Jobs", doc.Info.Title)
+		assert.Contains(t, doc.Paths.MapOfPathItemValues, "/jobs")
+
+		doc.Info.Title = "Job API"
+		schema, err := r.MarshalYAML()
+		require.NoError(t, err)
+		assert.Contains(t, string(schema), "title: Job API")
+	})
+
+	t.Run("OpenAPI 3.1", func(t *testing.T) {
+		r := spec.NewRouter(option.WithOpenAPIVersion("3.1.0"))
+		r.Get("/jobs", option.Response(200, new([]Job)))
+
+		doc, ok := r.Document().(*openapi31.Spec)
+		require.True(t, ok)
+		assert.Contains(t, doc.Paths.MapOfPathItemValues, "/jobs")
+	})
+
+	t.Run("Unsupported Version", func(t *testing.T) {
+		r := spec.NewRouter(option.WithOpenAPIVersion("2.0"))
+		assert.Nil(t, r.Document())
+	})
+}
+
+func TestRouter_LateRoutes(t *testing.T) {
+	r := spec.NewRouter()
+	r.Get("/jobs", option.Response(200, new([]Job)))
+	require.NoError(t, r.Validate())
+
+	jobs := r.Group("/jobs")
+	route := jobs.Post("", option.Request(new(Job)), option.Response(201, new(Job)))
+	schema, err := r.MarshalYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(schema), "    post:\n")
+
+	route.With(option.Summary("Create job"))
+	jobs.With(option.GroupTags("jobs"))
+	schema, err = r.MarshalYAML()
+	require.NoError(t, err)
+	assert.Contains(t, string(schema), "summary: Create job")
+	assert.Contains(t, string(schema), "- jobs")
+
+	r.Get("/jobs/{id}", option.Response(200, new(Job)))
+	err = r.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `GET /jobs/{id}: path parameter "id" is not declared`)
+}
+
+func TestRouter_ConcurrentGeneration(t *testing.T) {
+	r := spec.NewRouter()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func(version int) {
+			defer wg.Done()
+			r.Group(fmt.Sprintf("/v%d", version)).Get("/jobs", option.Response(200, new([]Job)))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := r.MarshalJSON()
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	var doc struct {
+		Paths map[string]any `json:"paths"`
+	}
+	schema, err := r.MarshalJSON()
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(schema, &doc))
+	assert.Len(t, doc.Paths, 10)
+	assert.Len(t, r.Operations(), 10)
+}
+
+func TestRouter_ReentrantBuild(t *testing.T) {
+	var r spec.Generator
+	r = spec.NewRouter(
+		option.WithOperationIDFunc(func(method, path string, _ *option.OperationConfig) string {
+			return option.OperationIDCamelCase(method, path, nil) + r.Config().Title
+		}),
+		option.WithSpecTransformer3(func(doc *openapi3.Spec) error {
+			doc.WithMapOfAnythingItem("x-operations", len(r.Operations()))
+			return nil
+		}),
+	)
+	r.Get("/jobs", option.Response(200, new([]Job)))
+	r.Post("/jobs", option.Response(201, new(Job)))
+
+	done := make(chan struct{})
+	var schema []byte
+	var err error
+	go func() {
+		defer close(done)
+		schema, err = r.MarshalJSON()
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("building the spec from a transformer that uses the generator did not finish")
+	}
+	require.NoError(t, err)
+
+	var doc struct {
+		Operations int `json:"x-operations"`
+	}
+	require.NoError(t, json.Unmarshal(schema, &doc))
+	assert.Equal(t, 2, doc.Operations)
+}
+
+func TestRouter_ConcurrentRegistration(t *testing.T) {
+	r := spec.NewRouter(option.WithOpenAPIVersion("3.1.0"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			version := fmt.Sprintf("/v%d", i)
+			defer wg.Done()
+			r.Route(version, func(api spec.Router) {
+				api.With(option.GroupTags("jobs")).Get("/jobs", option.Response(200, new([]Job)))
+				api.NewRoute(option.Summary("Create job")).Method("POST").Path("/jobs").
+					With(option.Response(202, new(Job)))
+			})
+			r.Webhook(fmt.Sprintf("jobDone%d", i), "POST", option.Request(new(Job)))
+			assert.NotEmpty(t, r.Operations())
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, r.Operations(), 20)
+	require.NoError(t, r.Validate())
+}
+
+func TestRouter_ResponseLinks(t *testing.T) {
+	for _, version := range []string{"3.0.3", "3.1.0"} {
+		r := spec.NewRouter(option.WithOpenAPIVersion(version))
+		r.Post("/jobs",
+			option.Request(new(CreateJobRequest)),
+			option.Response(202, new(Job),
+				option.ResponseLink("GetJob", option.LinkOperationID("getJob")),
+			),
+			option.Callback("onComplete", "{$request.body#/callback_url}", func(cb option.CallbackRouter) {
+				cb.Post(
+					option.OperationID("jobCompleted"),
+					option.Response(200, nil, option.ResponseLink("Retry", option.LinkOperationID("retryJob"))),
+				)
+			}),
+		)
+		r.Get("/jobs/{jobId}",
+			option.Request(new(GetJobRequest)),
+			option.Response(200, new(Job), option.ResponseLink("Self", option.LinkOperationID("jobCompleted"))),
+		)
+
+		err := r.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			`link "GetJob" in response 202 of POST /jobs refers to undefined operationId "getJob"`)
+		assert.Contains(t, err.Error(),
+			`link "Retry" in response 200 of POST {$request.body#/callback_url} refers to undefined operationId "retryJob"`)
+		assert.NotContains(t, err.Error(), `"Self"`)
+	}
+}
@@ -0,0 +1,101 @@
+package spec
+
+import (
+	"github.com/oaswrap/spec/internal/document"
+	"github.com/oaswrap/spec/option"
+	"github.com/swaggest/openapi-go/openapi3"
+	"github.com/swaggest/openapi-go/openapi31"
+)
+
+// securityRequirement returns the requirement object of a security config, mapping the
+// name of each scheme to its scopes. It is empty for an optional requirement.
+func securityRequirement(sec option.OperationSecurityConfig) map[string][]string {
+	requirement := map[string][]string{}
+	schemes := sec.All
+	if sec.Name != "" {
+		schemes = append([]option.OperationSecurityConfig{sec}, schemes...)
+	}
+	for _, scheme := range schemes {
+		scopes := requirement[scheme.Name]
+		if scopes == nil {
+			scopes = []string{}
+		}
+		requirement[scheme.Name] = append(scopes, scheme.Scopes...)
+	}
+	return requirement
+}
+
+// addSecurity adds a security requirement to the operation.
+func (oc *operationContextImpl) addSecurity(sec option.OperationSecurityConfig) {
+	if sec.Name != "" && len(sec.All) == 0 {
+		oc.op.AddSecurity(sec.Name, sec.Scopes...)
+		return
+	}
+	requirement := securityRequirement(sec)
+	switch op := oc.op.(type) {
+	case openapi3.OperationExposer:
+		o := op.Operation()
+		o.Security = append(o.Security, requirement)
+	case openapi31.OperationExposer:
+		o := op.Operation()
+		o.Security = append(o.Security, requirement)
+	}
+}
+
+// setPublic documents the operation with an empty security array, which overrides the
+// requirements declared at the top level of the document.
+func (oc *operationContextImpl) setPublic() {
+	switch op := oc.op.(type) {
+	case openapi3.OperationExposer:
+		op.Operation().Security = []map[string][]string{}
+	case openapi31.OperationExposer:
+		op.Operation().Security = []map[string][]string{}
+	}
+}
+
+// markPublic3 keeps the empty security array of the public operations with their
+// extensions before the spec is marshaled, as the security field is omitted when it is
+// empty. The extension is removed from the operations that are no longer public, so a
+// spec transformer can still set their requirements.
+func markPublic3(spec *openapi3.Spec) {
+	for _, item := range spec.Paths.MapOfPathItemValues {
+		for method, op := range item.MapOfOperationValues {
+			if op.Security == nil {
+				continue
+			}
+			delete(op.MapOfAnything, "security")
+			if len(op.Security) == 0 {
+				op.WithMapOfAnythingItem("security", []any{})
+			}
+			item.MapOfOperationValues[method] = op
+		}
+	}
+}
+
+// markPublic31 is the OpenAPI 3.1 variant of markPublic3, which also covers webhooks.
+func markPublic31(spec *openapi31.Spec) {
+	items := make([]*openapi31.PathItem, 0)
+	if spec.Paths != nil {
+		for path := range spec.Paths.MapOfPathItemValues {
+			item := spec.Paths.MapOfPathItemValues[path]
+			items = append(items, &item)
+		}
+	}
+	for _, webhook := range spec.Webhooks {
+		if webhook.PathItem != nil {
+			items = append(items, webhook.PathItem)
+		}
+	}
+	for _, item := range items {
+		for _, method := range document.Methods {
+			op, _ := item.Operation(method)
+			if op == nil || op.Security == nil {
+				continue
+			}
+			delete(op.MapOfAnything, "security")
+			if len(op.Security) == 0 {
+				op.WithMapOfAnythingItem("security", []any{})
+			}
+		}
+	}
+}
@@ -7,6 +7,9 @@ import (
 )
 
 // Generator defines an interface for building and exporting OpenAPI specifications.
+//
+// The specification is built when it is first used and built again once routes change,
+// so routes may be registered at any time, also while the specification is served.
 type Generator interface {
 	Router
 
@@ -23,14 +26,43 @@ type Generator interface {
 	// MarshalJSON returns the OpenAPI specification marshaled as JSON.
 	MarshalJSON() ([]byte, error)
 
+	// Webhook registers an outgoing webhook with the given name, HTTP method, and options.
+	//
+	// Use option.Request to describe the payload sent to subscribers and option.Response
+	// for the responses they are expected to return. Webhooks require OpenAPI 3.1.
+	Webhook(name, method string, opts ...option.OperationOption)
+
 	// Validate checks whether the OpenAPI specification is valid.
-	Validate() error
+	//
+	// Pass WithSchemaValidation to also validate the document against the official
+	// OpenAPI JSON Schema.
+	Validate(opts ...ValidateOption) error
+
+	// Document returns the built document, a *openapi3.Spec or *openapi31.Spec of
+	// github.com/swaggest/openapi-go depending on the OpenAPI version, or nil if the
+	// version is not supported. Changes to it are included when the spec is marshaled,
+	// until routes change and the spec is built again, but they are not checked by
+	// Validate, which reports the problems found when the spec was built. Use
+	// option.WithSpecTransformer for changes that must last and be validated.
+	Document() any
+
+	// Operations returns the registered operations in registration order, with the options
+	// of their groups applied. Webhooks are not included.
+	Operations() []Operation
 
 	// WriteSchemaTo writes the OpenAPI schema to a file.
 	// The format is inferred from the file extension: ".yaml" for YAML, ".json" for JSON.
 	WriteSchemaTo(path string) error
 }
 
+// Operation describes a registered operation, as returned by Generator.Operations.
+type Operation struct {
+	Method string                  // Upper-case HTTP method.
+	Path   string                  // OpenAPI path template, after the path parser is applied.
+	Config *option.OperationConfig // Effective configuration, including the options of the groups.
+	Hidden bool                    // Whether the operation is excluded from the specification.
+}
+
 // Router defines methods for registering API routes and operations
 // in an OpenAPI specification. It lets you describe HTTP methods, paths, and options.
 type Router interface {
@@ -87,6 +119,10 @@ type Route interface {
 
 type reflector interface {
 	Add(method, path string, opts ...option.OperationOption)
+	AddWebhook(name, method string, opts ...option.OperationOption)
+	Merge(fragments []specopenapi.Fragment)
+	Transform(transformers []specopenapi.SpecTransformer)
+	Check()
 	Spec() spec
 	Validate() error
 }
@@ -98,5 +134,7 @@ type spec interface {
 
 type operationContext interface {
 	With(opts ...option.OperationOption) operationContext
-	build() openapi.OperationContext
+	config() *option.OperationConfig
+	hidden() bool
+	build() (openapi.OperationContext, error)
 }
@@ -0,0 +1,104 @@
+package spec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/oaswrap/spec/internal/document"
+	"github.com/oaswrap/spec/internal/errs"
+)
+
+// ValidateOption configures Generator.Validate.
+type ValidateOption func(*validateConfig)
+
+type validateConfig struct {
+	schemaValidation bool
+}
+
+// WithSchemaValidation also validates the marshaled document against the official
+// OpenAPI 3.0 or 3.1 JSON Schema, which are embedded so no network access is needed.
+//
+// It reports structural problems the generator does not check, such as a missing
+// info.version or a server variable without a default value, as *SchemaError values.
+func WithSchemaValidation() ValidateOption {
+	return func(c *validateConfig) {
+		c.schemaValidation = true
+	}
+}
+
+// SchemaError describes a part of the document that violates the OpenAPI JSON Schema.
+type SchemaError struct {
+	Pointer string // JSON pointer to the invalid value, e.g. "#/servers/0/url".
+	Message string // Description of the violation.
+}
+
+// Error implements the error interface.
+func (e *SchemaError) Error() string {
+	return fmt.Sprintf("openapi schema: %s: %s", e.Pointer, e.Message)
+}
+
+// validateSchema validates the marshaled spec against the OpenAPI JSON Schema.
+func validateSchema(s spec) error {
+	data, err := s.MarshalJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec for schema validation: %w", err)
+	}
+	doc, err := document.Decode(data)
+	if err != nil {
+		return fmt.Errorf("failed to decode spec for schema validation: %w", err)
+	}
+	// The title and version are always marshaled, so they are empty rather than missing
+	// when they are not set.
+	info := document.Map(doc, "info")
+	for _, key := range []string{"title", "version"} {
+		if document.String(info, key) == "" {
+			delete(info, key)
+		}
+	}
+
+	expandServerURLs(doc)
+
+	violations, err := document.ValidateOpenAPI(doc)
+	if err != nil {
+		return err
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	e := &errs.SpecError{}
+	for _, v := range violations {
+		e.Add(&SchemaError{Pointer: "#" + v.Pointer, Message: v.Message})
+	}
+	return e
+}
+
+// expandServerURLs replaces the variables of the server URLs with their default values,
+// as clients do, so templated URLs are checked as the URLs they stand for.
+func expandServerURLs(doc map[string]any) {
+	expand := func(servers []any) {
+		for _, item := range servers {
+			server, _ := item.(map[string]any)
+			url := document.String(server, "url")
+			variables := document.Map(server, "variables")
+			for _, name := range document.SortedKeys(variables) {
+				value := document.String(document.Map(variables, name), "default")
+				if value == "" {
+					value = name
+				}
+				url = strings.ReplaceAll(url, "{"+name+"}", value)
+			}
+			if url != "" {
+				server["url"] = url
+			}
+		}
+	}
+	expand(document.Slice(doc, "servers"))
+	paths := document.Map(doc, "paths")
+	for path := range paths {
+		item := document.Map(paths, path)
+		expand(document.Slice(item, "servers"))
+		for _, method := range document.Methods {
+			expand(document.Slice(document.Map(item, method), "servers"))
+		}
+	}
+}